@@ -1,8 +1,6 @@
 # Xilution Organization
 
-data "xilution_organization" "xilution" {
-  id = local.organization_id
-}
+data "xilution_organization" "xilution" {}
 
 output "xilution_organization" {
   value = data.xilution_organization.xilution
@@ -11,8 +9,7 @@ output "xilution_organization" {
 # Xilution Client
 
 data "xilution_client" "terraform_client" {
-  id              = local.client_id
-  organization_id = local.organization_id
+  id = local.client_id
 }
 
 output "xilution_client" {
@@ -22,8 +19,7 @@ output "xilution_client" {
 # Xilution User
 
 data "xilution_user" "tbrunia" {
-  id              = local.user_id
-  organization_id = local.organization_id
+  id = local.user_id
 }
 
 output "xilution_user" {
@@ -33,15 +29,12 @@ output "xilution_user" {
 # Xilution Git Account
 
 # resource "xilution_git_account" "xilution_git_account" {
-#   name            = "xilution"
-#   git_provider    = "GIT_HUB"
-#   organization_id = local.organization_id
-#   owning_user_id  = local.user_id
+#   name         = "xilution"
+#   git_provider = "GIT_HUB"
 # }
 
 # data "xilution_git_account" "xilution_git_account" {
-#   id              = xilution_git_account.xilution_git_account.id
-#   organization_id = local.organization_id
+#   id = xilution_git_account.xilution_git_account.id
 # }
 
 # output "xilution_git_account" {
@@ -51,15 +44,12 @@ output "xilution_user" {
 # Xilution Git Repo
 
 # resource "xilution_git_repo" "xilution_temp_git_repo" {
-#   name            = "xilution-temp"
-#   organization_id = local.organization_id
-#   owning_user_id  = local.user_id
-#   git_account_id  = xilution_git_account.xilution_git_account.id
+#   name           = "xilution-temp"
+#   git_account_id = xilution_git_account.xilution_git_account.id
 # }
 
 # data "xilution_git_repo" "xilution_temp_git_repo" {
-#   id              = xilution_git_repo.xilution_temp_git_repo.id
-#   organization_id = local.organization_id
+#   id = xilution_git_repo.xilution_temp_git_repo.id
 # }
 
 # output "xilution_git_repo" {
@@ -67,26 +57,29 @@ output "xilution_user" {
 # }
 
 # Xilution Git Repo Event
+#
+# Changing any argument of a git repo event replaces it, which runs the event
+# again against the existing repo.
 
 # resource "xilution_git_repo_event" "xilution_temp_git_repo_event" {
-#   organization_id = local.organization_id
-#   owning_user_id  = local.user_id
-#   git_account_id  = xilution_git_account.xilution_git_account.id
-#   git_repo_id     = xilution_git_repo.xilution_temp_git_repo.id
-#   event_type      = "CREATE_REPO_FROM_TEMPLATE_REPO"
-#   parameters = jsonencode({
-#     "sourceOwner" : "xilution",
-#     "sourceRepo" : "xilution-bison-poc-template",
-#     "description" : "A new repo from a copy",
-#     "commitMessage" : "Initial repo setup",
-#     "isPrivate" : true,
-#     "params" : "{\"world\": \"planet\"}"
-#   })
+#   git_account_id = xilution_git_account.xilution_git_account.id
+#   git_repo_id    = xilution_git_repo.xilution_temp_git_repo.id
+#   event_type     = "CREATE_REPO_FROM_TEMPLATE_REPO"
+#
+#   create_repo_from_template {
+#     source_owner   = "xilution"
+#     source_repo    = "xilution-bison-poc-template"
+#     description    = "A new repo from a copy"
+#     commit_message = "Initial repo setup"
+#     is_private     = true
+#     template_params = {
+#       world = "planet"
+#     }
+#   }
 # }
 
 # data "xilution_git_repo_event" "xilution_temp_git_repo_event" {
-#   id              = xilution_git_repo_event.xilution_temp_git_repo_event.id
-#   organization_id = local.organization_id
+#   id = xilution_git_repo_event.xilution_temp_git_repo_event.id
 # }
 
 # output "xilution_temp_git_repo_event" {
@@ -96,18 +89,16 @@ output "xilution_user" {
 # Xilution Cloud Provider
 
 # resource "xilution_cloud_provider" "xilution_cloud_provider" {
-#   organization_id = local.organization_id
-#   owning_user_id  = local.user_id
-#   name            = "Xilution AWS (Prod)"
-#   cloud_provider  = "AWS"
-#   account_id      = "952573012699"
-#   region          = "us-east-1"
+#   name           = "Xilution AWS (Prod)"
+#   cloud_provider = "AWS"
+#   account_id     = "952573012699"
+#   region         = "us-east-1"
 # }
 
 data "xilution_cloud_provider" "xilution_cloud_provider" {
-  # id              = xilution_cloud_provider.xilution_cloud_provider.id
-  id              = var.CLOUD_PROVIDER_ID
-  organization_id = local.organization_id
+  # id = xilution_cloud_provider.xilution_cloud_provider.id
+  # name = "Xilution AWS (Prod)"
+  id = var.CLOUD_PROVIDER_ID
 }
 
 output "xilution_cloud_provider" {
@@ -117,35 +108,43 @@ output "xilution_cloud_provider" {
 # Xilution VPC Pipeline
 
 resource "xilution_vpc_pipeline" "xilution_vpc_pipeline" {
-  organization_id = local.organization_id
-  owning_user_id  = local.user_id
-  pipeline_type   = "AWS_SMALL"
-  name            = "VPC 1"
+  pipeline_type = "AWS_SMALL"
+  name          = "VPC 1"
   # cloud_provider_id = xilution_cloud_provider.xilution_cloud_provider.id
   cloud_provider_id = data.xilution_cloud_provider.xilution_cloud_provider.id
 }
 
 data "xilution_vpc_pipeline" "xilution_vpc_pipeline" {
-  id              = xilution_vpc_pipeline.xilution_vpc_pipeline.id
-  organization_id = local.organization_id
+  id = xilution_vpc_pipeline.xilution_vpc_pipeline.id
 }
 
 output "xilution_vpc_pipeline" {
   value = data.xilution_vpc_pipeline.xilution_vpc_pipeline
 }
 
+data "xilution_vpc_pipelines" "small_vpc_pipelines" {
+  name_regex    = "^VPC"
+  pipeline_type = "AWS_SMALL"
+}
+
+output "small_vpc_pipeline_ids" {
+  value = data.xilution_vpc_pipelines.small_vpc_pipelines.ids
+}
+
 # Xilution VPC Pipeline Provision Event
+#
+# on_destroy = "DEPROVISION" is only allowed on PROVISION events. A change to
+# triggers replaces the event, which deprovisions the pipeline and then
+# provisions it again.
 
 resource "xilution_vpc_pipeline_event" "xilution_vpc_pipeline_provision_event" {
-  organization_id = local.organization_id
-  owning_user_id  = local.user_id
-  pipeline_id     = xilution_vpc_pipeline.xilution_vpc_pipeline.id
-  event_type      = "PROVISION"
+  pipeline_id = xilution_vpc_pipeline.xilution_vpc_pipeline.id
+  event_type  = "PROVISION"
+  on_destroy  = "DEPROVISION"
 }
 
 data "xilution_vpc_pipeline_event" "xilution_vpc_pipeline_provision_event" {
-  id              = xilution_vpc_pipeline_event.xilution_vpc_pipeline_provision_event.id
-  organization_id = local.organization_id
+  id = xilution_vpc_pipeline_event.xilution_vpc_pipeline_provision_event.id
 }
 
 output "xilution_vpc_pipeline_provision_event" {
@@ -155,16 +154,17 @@ output "xilution_vpc_pipeline_provision_event" {
 # Xilution K8s Pipeline
 
 # resource "xilution_k8s_pipeline" "xilution_k8s_pipeline" {
-#   organization_id   = local.organization_id
-#   owning_user_id    = local.user_id
-#   pipeline_type     = "AWS_SMALL"
-#   name              = "K8S 1"
+#   pipeline_type   = "AWS_SMALL"
+#   name            = "K8S 1"
 #   vpc_pipeline_id = xilution_vpc_pipeline.xilution_vpc_pipeline.id
+#   provisioned     = true
+#   timeouts {
+#     delete = "60m"
+#   }
 # }
 
 # data "xilution_k8s_pipeline" "xilution_k8s_pipeline" {
-#   id              = xilution_k8s_pipeline.xilution_k8s_pipeline.id
-#   organization_id = local.organization_id
+#   id = xilution_k8s_pipeline.xilution_k8s_pipeline.id
 # }
 
 # output "xilution_k8s_pipeline" {
@@ -174,10 +174,8 @@ output "xilution_vpc_pipeline_provision_event" {
 # Xilution WordPress Pipeline
 
 # resource "xilution_word_press_pipeline" "xilution_word_press_pipeline" {
-#   organization_id   = local.organization_id
-#   owning_user_id    = local.user_id
-#   pipeline_type     = "AWS_SMALL"
-#   name              = "WordPress 1"
+#   pipeline_type = "AWS_SMALL"
+#   name          = "WordPress 1"
 #   k8s_pipeline_id = xilution_k8s_pipeline.xilution_k8s_pipeline.id
 #   stages {
 #     name = "test"
@@ -190,8 +188,7 @@ output "xilution_vpc_pipeline_provision_event" {
 # }
 
 # data "xilution_word_press_pipeline" "xilution_word_press_pipeline" {
-#   id              = xilution_word_press_pipeline.xilution_word_press_pipeline.id
-#   organization_id = local.organization_id
+#   id = xilution_word_press_pipeline.xilution_word_press_pipeline.id
 # }
 
 # output "xilution_word_press_pipeline" {
@@ -201,8 +198,6 @@ output "xilution_vpc_pipeline_provision_event" {
 # Xilution Static Content Pipeline
 
 # resource "xilution_static_content_pipeline" "xilution_static_content_pipeline" {
-#   organization_id   = local.organization_id
-#   owning_user_id    = local.user_id
 #   pipeline_type     = "AWS_SMALL"
 #   name              = "Static Site 1"
 #   cloud_provider_id = xilution_cloud_provider.xilution_cloud_provider.id
@@ -217,8 +212,7 @@ output "xilution_vpc_pipeline_provision_event" {
 # }
 
 # data "xilution_static_content_pipeline" "xilution_static_content_pipeline" {
-#   id              = xilution_static_content_pipeline.xilution_static_content_pipeline.id
-#   organization_id = local.organization_id
+#   id = xilution_static_content_pipeline.xilution_static_content_pipeline.id
 # }
 
 # output "xilution_static_content_pipeline" {
@@ -228,8 +222,6 @@ output "xilution_vpc_pipeline_provision_event" {
 # Xilution API Pipeline
 
 # resource "xilution_api_pipeline" "xilution_api_pipeline" {
-#   organization_id = local.organization_id
-#   owning_user_id  = local.user_id
 #   pipeline_type   = "AWS_SMALL"
 #   name            = "API 1"
 #   vpc_pipeline_id = xilution_vpc_pipeline.xilution_vpc_pipeline.id
@@ -244,8 +236,7 @@ output "xilution_vpc_pipeline_provision_event" {
 # }
 
 # data "xilution_api_pipeline" "xilution_api_pipeline" {
-#   id              = xilution_api_pipeline.xilution_api_pipeline.id
-#   organization_id = local.organization_id
+#   id = xilution_api_pipeline.xilution_api_pipeline.id
 # }
 
 # output "xilution_api_pipeline" {
@@ -3,6 +3,8 @@ module github.com/xilution/terraform-provider-xilution
 go 1.16
 
 require (
+	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
+	github.com/hashicorp/go-retryablehttp v0.7.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.0
 	github.com/xilution/xilution-client-go v0.0.0-20210811042517-5657802f0df4
 )
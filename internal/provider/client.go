@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+// xilutionClient is the part of the Xilution API the provider uses. It is
+// satisfied by *xc.XilutionClient and by the in-memory fake used in tests.
+type xilutionClient interface {
+	GetOrganization(organizationId *string) (*xc.Organization, error)
+
+	GetClient(organizationId *string, clientId *string) (*xc.Client, error)
+	GetClients(organizationId *string, pageSize, pageNumber *int) (*xc.FetchClientsResponse, error)
+
+	GetUser(organizationId *string, userId *string) (*xc.User, error)
+	GetUsers(organizationId *string, pageSize, pageNumber *int) (*xc.FetchUsersResponse, error)
+
+	CreateGitAccount(organizationId *string, gitAccount *xc.GitAccount) (*string, error)
+	GetGitAccount(organizationId *string, gitAccountId *string) (*xc.GitAccount, error)
+	GetGitAccounts(organizationId *string, pageSize, pageNumber *int) (*xc.FetchGitAccountsResponse, error)
+	UpdateGitAccount(organizationId *string, gitAccount *xc.GitAccount) error
+	DeleteGitAccount(organizationId *string, gitAccountId *string) error
+
+	CreateGitRepo(organizationId *string, gitRepo *xc.GitRepo) (*string, error)
+	GetGitRepo(organizationId, gitRepoId *string) (*xc.GitRepo, error)
+	GetGitRepos(organizationId *string, pageSize, pageNumber *int) (*xc.FetchGitReposResponse, error)
+	UpdateGitRepo(organizationId *string, gitRepo *xc.GitRepo) error
+	DeleteGitRepo(organizationId, gitRepoId *string) error
+
+	CreateGitRepoEvent(organizationId *string, gitRepoEvent *xc.GitRepoEvent) (*string, error)
+	GetGitRepoEvent(organizationId, eventId *string) (*xc.GitRepoEvent, error)
+	GetGitRepoEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchGitRepoEventsResponse, error)
+
+	CreateCloudProvider(organizationId *string, cloudProvider *xc.CloudProvider) (*string, error)
+	GetCloudProvider(organizationId *string, cloudProviderId *string) (*xc.CloudProvider, error)
+	GetCloudProviders(organizationId *string, pageSize, pageNumber *int) (*xc.FetchCloudProvidersResponse, error)
+	UpdateCloudProvider(organizationId *string, cloudProvider *xc.CloudProvider) error
+	DeleteCloudProvider(organizationId *string, cloudProviderId *string) error
+
+	CreateVpcPipeline(organizationId *string, pipeline *xc.VpcPipeline) (*string, error)
+	GetVpcPipeline(organizationId *string, pipeline *string) (*xc.VpcPipeline, error)
+	GetVpcPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchVpcPipelinesResponse, error)
+	UpdateVpcPipeline(organizationId *string, vpcPipeline *xc.VpcPipeline) error
+	DeleteVpcPipeline(organizationId *string, pipeline *string) error
+	CreateVpcPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error)
+	GetVpcPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error)
+	GetVpcPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error)
+
+	CreateK8sPipeline(organizationId *string, k8sPipeline *xc.K8sPipeline) (*string, error)
+	GetK8sPipeline(organizationId *string, k8sPipelineId *string) (*xc.K8sPipeline, error)
+	GetK8sPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchK8sPipelinesResponse, error)
+	UpdateK8sPipeline(organizationId *string, k8sPipeline *xc.K8sPipeline) error
+	DeleteK8sPipeline(organizationId *string, k8sPipelineId *string) error
+	CreateK8sPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error)
+	GetK8sPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error)
+	GetK8sPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error)
+
+	CreateWordPressPipeline(organizationId *string, wordPress *xc.WordPressPipeline) (*string, error)
+	GetWordPressPipeline(organizationId *string, wordPressId *string) (*xc.WordPressPipeline, error)
+	GetWordPressPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchWordPressPipelinesResponse, error)
+	UpdateWordPressPipeline(organizationId *string, wordPress *xc.WordPressPipeline) error
+	DeleteWordPressPipeline(organizationId *string, wordPressId *string) error
+	CreateWordPressPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error)
+	GetWordPressPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error)
+	GetWordPressPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error)
+
+	CreateStaticContentPipeline(organizationId *string, staticContent *xc.StaticContentPipeline) (*string, error)
+	GetStaticContentPipeline(organizationId *string, staticContentId *string) (*xc.StaticContentPipeline, error)
+	GetStaticContentPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchStaticContentPipelinesResponse, error)
+	UpdateStaticContentPipeline(organizationId *string, staticContent *xc.StaticContentPipeline) error
+	DeleteStaticContentPipeline(organizationId *string, staticContentId *string) error
+	CreateStaticContentPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error)
+	GetStaticContentPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error)
+	GetStaticContentPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error)
+
+	CreateApiPipeline(organizationId *string, api *xc.ApiPipeline) (*string, error)
+	GetApiPipeline(organizationId *string, apiId *string) (*xc.ApiPipeline, error)
+	GetApiPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchApiPipelinesResponse, error)
+	UpdateApiPipeline(organizationId *string, api *xc.ApiPipeline) error
+	DeleteApiPipeline(organizationId *string, apiId *string) error
+	CreateApiPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error)
+	GetApiPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error)
+	GetApiPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error)
+
+	CreatePipelinePrototype(organizationId *string, pipelinePrototype *xc.PipelinePrototype) (*string, error)
+	GetPipelinePrototype(organizationId, pipelinePrototypeId *string) (*xc.PipelinePrototype, error)
+	GetPipelinePrototypes(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelinePrototypesResponse, error)
+	UpdatePipelinePrototype(organizationId *string, pipelinePrototype *xc.PipelinePrototype) error
+	DeletePipelinePrototype(organizationId *string, pipelinePrototypeId *string) error
+}
+
+var _ xilutionClient = (*xc.XilutionClient)(nil)
+
+// baseUrlHttpClient sends requests meant for a Xilution product API, such as
+// https://gazelle.basics.api.xilution.com/organizations/..., to another
+// endpoint. An endpoint containing {product}, e.g.
+// https://{product}.api.staging.example.com, gets the product name filled in.
+// Any other endpoint serves every product under its own path, e.g.
+// <endpoint>/gazelle/organizations/..., so the whole API can be served from a
+// single host. Requests for the zebra auth API go to the auth endpoint, and
+// requests without an endpoint go out unchanged.
+type baseUrlHttpClient struct {
+	httpClient   xc.IHttpClient
+	apiEndpoint  string
+	authEndpoint string
+}
+
+func (c *baseUrlHttpClient) Do(req *retryablehttp.Request) (*http.Response, error) {
+	product := strings.SplitN(req.URL.Hostname(), ".", 2)[0]
+
+	endpoint := c.apiEndpoint
+	if product == "zebra" {
+		endpoint = c.authEndpoint
+	}
+	if endpoint == "" {
+		return c.httpClient.Do(req)
+	}
+
+	baseUrl, productPath, err := resolveEndpoint(endpoint, product)
+	if err != nil {
+		return nil, err
+	}
+
+	// The request is rewritten on a copy, so it can be sent again as is.
+	original := req
+	req = &retryablehttp.Request{}
+	*req = *original
+	req.Request = original.Request.Clone(original.Context())
+
+	req.URL.Scheme = baseUrl.Scheme
+	req.URL.Host = baseUrl.Host
+	req.URL.Path = path.Join("/", baseUrl.Path, productPath, req.URL.Path)
+	req.Host = baseUrl.Host
+
+	return c.httpClient.Do(req)
+}
+
+// notFoundError is a 404 response from the Xilution API. The Xilution client
+// keeps only the message of an error response, so the provider's HTTP client
+// turns 404 responses into this error before the client sees them.
+type notFoundError struct {
+	method  string
+	url     string
+	message string
+}
+
+func (e *notFoundError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+
+	return fmt.Sprintf("%s %s: not found", e.method, e.url)
+}
+
+// checkNotFound returns a notFoundError in place of a 404 response.
+func checkNotFound(req *retryablehttp.Request, res *http.Response, err error) (*http.Response, error) {
+	if err != nil || res.StatusCode != http.StatusNotFound {
+		return res, err
+	}
+
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+
+	errorResponse := xc.ErrorResponse{}
+	json.Unmarshal(body, &errorResponse)
+
+	return nil, &notFoundError{
+		method:  req.Method,
+		url:     req.URL.String(),
+		message: errorResponse.Message,
+	}
+}
+
+// resolveEndpoint returns the base url of a product's API at an endpoint and
+// the path the product is served under there.
+func resolveEndpoint(endpoint string, product string) (*url.URL, string, error) {
+	productPath := product
+	if strings.Contains(endpoint, "{product}") {
+		endpoint = strings.ReplaceAll(endpoint, "{product}", product)
+		productPath = ""
+	}
+
+	baseUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	if baseUrl.Scheme == "" || baseUrl.Host == "" {
+		return nil, "", fmt.Errorf("%s must be an absolute url", endpoint)
+	}
+
+	return baseUrl, productPath, nil
+}
+
+// newRetryableHttpClient returns the http client every Xilution API request
+// is sent with. It retries transient failures up to maxRetries times, backing
+// off exponentially from retryWaitMin to retryWaitMax or for as long as a 429
+// or 503 response's Retry-After header asks.
+func newRetryableHttpClient(transport http.RoundTripper, maxRetries int, retryWaitMin, retryWaitMax time.Duration) *retryablehttp.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = &http.Client{
+		Transport: transport,
+		Timeout:   30 * time.Second,
+	}
+	retryClient.Logger = nil
+	retryClient.RetryMax = maxRetries
+	retryClient.RetryWaitMin = retryWaitMin
+	retryClient.RetryWaitMax = retryWaitMax
+	retryClient.CheckRetry = checkRetry
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, retry int) {
+		if retry > 0 {
+			log.Printf("[DEBUG] Retrying %s %s (retry %d of %d)", req.Method, req.URL, retry, maxRetries)
+		}
+	}
+	retryClient.ErrorHandler = func(resp *http.Response, err error, attempts int) (*http.Response, error) {
+		if resp != nil {
+			log.Printf("[DEBUG] Giving up on %s %s after %d attempt(s)", resp.Request.Method, resp.Request.URL, attempts)
+		} else {
+			log.Printf("[DEBUG] Giving up on Xilution API request after %d attempt(s): %s", attempts, err)
+		}
+		return retryablehttp.PassthroughErrorHandler(resp, err, attempts)
+	}
+
+	return retryClient
+}
+
+// checkRetry decides whether a failed Xilution API request is retried.
+// Connection errors, 429 Too Many Requests and 5xx responses are transient.
+// POSTs create objects and events, so they are only retried when the API
+// cannot have acted on them: the connection was never made, or the response
+// is a 429 or a 503. Everything else, including certificate errors and other
+// 4xx responses, is terminal and handed back to the client as is.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) && urlErr.Op == "Post" && !isDialError(err) {
+			return false, nil
+		}
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		return true, nil
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return resp.Request.Method != http.MethodPost, nil
+	}
+
+	return false, nil
+}
+
+func isDialError(err error) bool {
+	var opErr *net.OpError
+
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func newHttpClient(apiEndpoint string, authEndpoint string, httpClient xc.IHttpClient) (xc.IHttpClient, error) {
+	if apiEndpoint == "" && authEndpoint == "" {
+		return httpClient, nil
+	}
+
+	for _, endpoint := range []string{apiEndpoint, authEndpoint} {
+		if endpoint == "" {
+			continue
+		}
+		if _, _, err := resolveEndpoint(endpoint, "zebra"); err != nil {
+			return nil, fmt.Errorf("invalid endpoint %s: %w", endpoint, err)
+		}
+	}
+
+	return &baseUrlHttpClient{
+		httpClient:   httpClient,
+		apiEndpoint:  apiEndpoint,
+		authEndpoint: authEndpoint,
+	}, nil
+}
+
+// newHttpTransport returns the transport Xilution API requests are sent over.
+// It trusts the system's certificate authorities plus those in caBundle,
+// unless insecureSkipVerify turns certificate verification off, and sends
+// requests through proxyUrl or, when that is empty, the proxy the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables choose.
+func newHttpTransport(insecureSkipVerify bool, caBundle string, proxyUrl string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+
+	if caBundle != "" {
+		path, err := expandHomeDir(caBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		pem, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_bundle: %w", err)
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_bundle %s contains no PEM encoded certificates", caBundle)
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	if proxyUrl != "" {
+		proxy, err := url.Parse(proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("proxy_url (%s) must be an absolute url", proxyUrl)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	return transport, nil
+}
+
+// requestAccessToken authenticates against the Xilution organization's OAuth
+// token endpoint with the given grant.
+func requestAccessToken(httpClient xc.IHttpClient, organizationId string, data url.Values) (*accessToken, error) {
+	req, err := retryablehttp.NewRequest("POST", fmt.Sprintf("%s/organizations/%s/oauth/token", xc.ZebraBaseUrl, organizationId), strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		errorResponse := xc.ErrorResponse{}
+		json.Unmarshal(body, &errorResponse)
+		return nil, fmt.Errorf("unable to authenticate with Xilution (%d): %s", res.StatusCode, errorResponse.Message)
+	}
+
+	tokenResponse := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return nil, err
+	}
+
+	return newAccessToken(tokenResponse.AccessToken, tokenResponse.ExpiresIn), nil
+}
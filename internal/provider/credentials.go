@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const defaultSharedCredentialsFile = "~/.xilution/credentials"
+const defaultProfile = "default"
+
+// sharedCredentialsKeys are the settings a shared credentials profile can
+// hold.
+var sharedCredentialsKeys = []string{"organization_id", "client_id", "client_secret", "scope", "grant_type"}
+
+// loadSharedCredentials returns the settings of a profile in a shared
+// credentials file such as
+//
+//	[default]
+//	organization_id = ...
+//	client_id       = ...
+//	client_secret   = ...
+//
+// When neither the file nor the profile were chosen explicitly, a missing
+// default file or profile is not an error and no settings are returned.
+func loadSharedCredentials(path, profile string) (map[string]string, error) {
+	explicit := path != "" || profile != ""
+	if path == "" {
+		path = defaultSharedCredentialsFile
+	}
+	if profile == "" {
+		profile = defaultProfile
+	}
+
+	expanded, err := expandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(expanded)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("unable to read shared credentials file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	profiles, err := parseSharedCredentials(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse shared credentials file %s: %w", path, err)
+	}
+
+	settings, ok := profiles[profile]
+	if !ok {
+		if !explicit {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("profile %q not found in shared credentials file %s", profile, path)
+	}
+
+	return settings, nil
+}
+
+// parseSharedCredentials reads the profiles of a shared credentials file.
+// Blank lines and lines starting with # or ; are ignored.
+func parseSharedCredentials(r io.Reader) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+
+	var settings map[string]string
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			profile := strings.TrimSpace(line[1 : len(line)-1])
+			if profile == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", lineNumber)
+			}
+			if _, ok := profiles[profile]; !ok {
+				profiles[profile] = map[string]string{}
+			}
+			settings = profiles[profile]
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("line %d: expected key = value", lineNumber)
+		}
+		if settings == nil {
+			return nil, fmt.Errorf("line %d: %s is not in a profile", lineNumber, strings.TrimSpace(parts[0]))
+		}
+
+		key := strings.TrimSpace(parts[0])
+		if !containsString(sharedCredentialsKeys, key) {
+			return nil, fmt.Errorf("line %d: unsupported key %s, expected one of %s", lineNumber, key, strings.Join(sharedCredentialsKeys, ", "))
+		}
+		settings[key] = strings.TrimSpace(parts[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// providerSetting returns a provider argument, which is already defaulted to
+// its XILUTION_* environment variable, falling back to the shared credentials
+// profile and then to defaultValue.
+func providerSetting(value string, sharedCredentials map[string]string, key string, defaultValue string) string {
+	if value != "" {
+		return value
+	}
+
+	if value, ok := sharedCredentials[key]; ok && value != "" {
+		return value
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testSharedCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	return path
+}
+
+func testSetenv(t *testing.T, key, value string) {
+	t.Helper()
+
+	previous, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestParseSharedCredentials(t *testing.T) {
+	profiles, err := parseSharedCredentials(strings.NewReader(`
+# Xilution credentials
+[default]
+organization_id = org-1
+client_id       = client-1
+client_secret   = secret=with=equals
+
+; CI
+[ ci ]
+grant_type = password
+scope      = read
+`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := map[string]map[string]string{
+		"default": {
+			"organization_id": "org-1",
+			"client_id":       "client-1",
+			"client_secret":   "secret=with=equals",
+		},
+		"ci": {
+			"grant_type": "password",
+			"scope":      "read",
+		},
+	}
+	if !reflect.DeepEqual(profiles, expected) {
+		t.Fatalf("unexpected profiles: %v", profiles)
+	}
+
+	for _, invalid := range []string{
+		"client_id = client-1",
+		"[default]\nclient_id",
+		"[default]\nclient_key = client-1",
+		"[]\nclient_id = client-1",
+	} {
+		if _, err := parseSharedCredentials(strings.NewReader(invalid)); err == nil {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestLoadSharedCredentials(t *testing.T) {
+	path := testSharedCredentialsFile(t, "[default]\nclient_id = client-1\n[ci]\nclient_id = client-2\n")
+
+	settings, err := loadSharedCredentials(path, "")
+	if err != nil || settings["client_id"] != "client-1" {
+		t.Fatalf("expected the default profile, got %v (%v)", settings, err)
+	}
+
+	settings, err = loadSharedCredentials(path, "ci")
+	if err != nil || settings["client_id"] != "client-2" {
+		t.Fatalf("expected the ci profile, got %v (%v)", settings, err)
+	}
+
+	if _, err := loadSharedCredentials(path, "prod"); err == nil {
+		t.Fatal("expected a missing profile to be an error")
+	}
+
+	if _, err := loadSharedCredentials(filepath.Join(t.TempDir(), "missing"), ""); err == nil {
+		t.Fatal("expected a missing shared credentials file to be an error")
+	}
+}
+
+func TestProvider_sharedCredentials(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	path := testSharedCredentialsFile(t, `
+[default]
+organization_id = org-2
+client_id       = client-2
+client_secret   = wrong
+
+[terraform]
+organization_id = `+mockOrganizationId+`
+client_id       = `+mockClientId+`
+client_secret   = `+mockClientSecret+`
+`)
+
+	config := map[string]interface{}{
+		"base_url":                api.URL(),
+		"shared_credentials_file": path,
+	}
+
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Fatal("expected the default profile's credentials to be used")
+	}
+
+	config["profile"] = "terraform"
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("configure: %v", diags)
+	}
+
+	testSetenv(t, "XILUTION_PROFILE", "terraform")
+	delete(config, "profile")
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("expected XILUTION_PROFILE to choose the profile, got %v", diags)
+	}
+
+	testSetenv(t, "XILUTION_CLIENT_SECRET", "wrong")
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Fatal("expected XILUTION_CLIENT_SECRET to take precedence over the profile")
+	}
+
+	config["client_secret"] = mockClientSecret
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("expected client_secret to take precedence over XILUTION_CLIENT_SECRET, got %v", diags)
+	}
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceApiPipeline() *schema.Resource {
@@ -13,12 +12,16 @@ func dataSourceApiPipeline() *schema.Resource {
 		ReadContext: dataSourceApiPipelineRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"pipeline_type": {
 				Type:     schema.TypeString,
@@ -51,7 +54,8 @@ func dataSourceApiPipeline() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -65,17 +69,48 @@ func dataSourceApiPipeline() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"infrastructure_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_up_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_down_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceApiPipelineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	apiPipelineId := d.Get("id").(string)
+	if apiPipelineId == "" {
+		apiPipelines, err := listApiPipelines(c, organizationId, newLookupFilter(d, "name"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, apiPipeline := range apiPipelines {
+			ids = append(ids, apiPipeline.ID)
+		}
+
+		apiPipelineId, err = lookupId("api pipeline", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	apiPipeline, err := c.GetApiPipeline(&organizationId, &apiPipelineId)
 	if err != nil {
@@ -129,6 +164,10 @@ func dataSourceApiPipelineRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	if err := setPipelineStatus(d, apiPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(apiPipeline.ID)
 
 	return diags
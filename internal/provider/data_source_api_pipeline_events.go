@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceApiPipelineEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceApiPipelineEventsRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"pipeline_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"event_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineEventTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"api_pipeline_events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceApiPipelineEvent().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceApiPipelineEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "pipeline_id", "event_type", "owning_user_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	apiPipelineEvents, err := listPipelineEvents(organizationId, filter, c.GetApiPipelineEvents)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	apiPipelineEventMaps := []interface{}{}
+	for _, apiPipelineEvent := range apiPipelineEvents {
+		ids = append(ids, apiPipelineEvent.ID)
+		apiPipelineEventMaps = append(apiPipelineEventMaps, flattenPipelineEvent(apiPipelineEvent))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("api_pipeline_events", apiPipelineEventMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionApiPipelineDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_api_pipeline.test"
+	dataSourceName := "data.xilution_api_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccApiPipelineConfig("API", "master") + `
+data "xilution_api_pipeline" "test" {
+  id              = xilution_api_pipeline.test.id
+  organization_id = xilution_api_pipeline.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_pipeline_id", resourceName, "vpc_pipeline_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_repo_id", resourceName, "git_repo_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "branch", resourceName, "branch"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "stages.#", resourceName, "stages.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceApiPipelines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceApiPipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"pipeline_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"api_pipelines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceApiPipeline().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceApiPipelinesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "pipeline_type", "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	apiPipelines, err := listApiPipelines(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	apiPipelineMaps := []interface{}{}
+	for _, apiPipeline := range apiPipelines {
+		ids = append(ids, apiPipeline.ID)
+		apiPipelineMaps = append(apiPipelineMaps, flattenApiPipeline(apiPipeline))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("api_pipelines", apiPipelineMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listApiPipelines pages through an organization's api pipelines and returns
+// the ones that pass the filter.
+func listApiPipelines(c xilutionClient, organizationId string, filter *listFilter) ([]xc.ApiPipeline, error) {
+	apiPipelines := []xc.ApiPipeline{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetApiPipelines(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, apiPipeline := range response.Content {
+			infrastructureStatus, _, _ := flattenPipelineStatus(apiPipeline.Status)
+			if filter.matches(apiPipeline.Name, map[string]string{
+				"pipeline_type":   apiPipeline.PipelineType,
+				"vpc_pipeline_id": apiPipeline.VpcPipelineId,
+				"owning_user_id":  apiPipeline.OwningUserId,
+				"status":          infrastructureStatus,
+			}) {
+				apiPipelines = append(apiPipelines, apiPipeline)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return apiPipelines, nil
+}
+
+func flattenApiPipeline(apiPipeline xc.ApiPipeline) map[string]interface{} {
+	infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus := flattenPipelineStatus(apiPipeline.Status)
+
+	stages := make([]interface{}, len(apiPipeline.Stages))
+	for i, stage := range apiPipeline.Stages {
+		stages[i] = map[string]interface{}{
+			"name": stage.Name,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                           apiPipeline.ID,
+		"name":                         apiPipeline.Name,
+		"pipeline_type":                apiPipeline.PipelineType,
+		"vpc_pipeline_id":              apiPipeline.VpcPipelineId,
+		"git_repo_id":                  apiPipeline.GitRepoId,
+		"stages":                       stages,
+		"organization_id":              apiPipeline.OrganizationId,
+		"owning_user_id":               apiPipeline.OwningUserId,
+		"created_at":                   apiPipeline.CreatedAt,
+		"modified_at":                  apiPipeline.ModifiedAt,
+		"infrastructure_status":        infrastructureStatus,
+		"latest_up_execution_status":   latestUpExecutionStatus,
+		"latest_down_execution_status": latestDownExecutionStatus,
+	}
+}
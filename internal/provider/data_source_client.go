@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceClient() *schema.Resource {
@@ -13,12 +12,16 @@ func dataSourceClient() *schema.Resource {
 		ReadContext: dataSourceClientRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"grants": {
 				Type: schema.TypeList,
@@ -40,7 +43,8 @@ func dataSourceClient() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -68,12 +72,31 @@ func dataSourceClient() *schema.Resource {
 }
 
 func dataSourceClientRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	clientId := d.Get("id").(string)
+	if clientId == "" {
+		clients, err := listClients(c, organizationId, newLookupFilter(d, "name"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, client := range clients {
+			ids = append(ids, client.ID)
+		}
+
+		clientId, err = lookupId("client", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	client, err := c.GetClient(&organizationId, &clientId)
 	if err != nil {
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionClientDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	dataSourceName := "data.xilution_client.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + fmt.Sprintf(`
+data "xilution_client" "test" {
+  id              = %q
+  organization_id = %q
+}
+`, mockClientId, mockOrganizationId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "name", "Terraform"),
+					resource.TestCheckResourceAttr(dataSourceName, "client_user_id", mockUserId),
+					resource.TestCheckResourceAttr(dataSourceName, "grants.#", "1"),
+				),
+			},
+		},
+	})
+}
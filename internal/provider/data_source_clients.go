@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceClients() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClientsRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"clients": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceClient().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceClientsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "owning_user_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	clients, err := listClients(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	clientMaps := []interface{}{}
+	for _, client := range clients {
+		ids = append(ids, client.ID)
+		clientMaps = append(clientMaps, flattenClient(client))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("clients", clientMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listClients pages through an organization's clients and returns the ones that
+// pass the filter.
+func listClients(c xilutionClient, organizationId string, filter *listFilter) ([]xc.Client, error) {
+	clients := []xc.Client{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetClients(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, client := range response.Content {
+			if filter.matches(client.Name, map[string]string{
+				"owning_user_id": client.OwningUserId,
+			}) {
+				clients = append(clients, client)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return clients, nil
+}
+
+func flattenClient(client xc.Client) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              client.ID,
+		"name":            client.Name,
+		"grants":          client.Grants,
+		"redirect_uris":   client.RedirectUris,
+		"client_user_id":  client.ClientUserId,
+		"organization_id": client.OrganizationId,
+		"owning_user_id":  client.OwningUserId,
+		"active":          client.Active,
+		"created_at":      client.CreatedAt,
+		"modified_at":     client.ModifiedAt,
+		"secret":          client.Secret,
+	}
+}
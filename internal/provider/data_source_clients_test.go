@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionClientsDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	dataSourceName := "data.xilution_clients.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + fmt.Sprintf(`
+data "xilution_clients" "test" {
+  owning_user_id = %q
+}
+`, mockUserId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "ids.0", mockClientId),
+					resource.TestCheckResourceAttr(dataSourceName, "clients.0.name", "Terraform"),
+					resource.TestCheckResourceAttr(dataSourceName, "clients.0.client_user_id", mockUserId),
+				),
+			},
+		},
+	})
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceCloudProvider() *schema.Resource {
@@ -13,12 +12,16 @@ func dataSourceCloudProvider() *schema.Resource {
 		ReadContext: dataSourceCloudProviderRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"cloud_provider": {
 				Type:     schema.TypeString,
@@ -34,7 +37,8 @@ func dataSourceCloudProvider() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -53,12 +57,31 @@ func dataSourceCloudProvider() *schema.Resource {
 }
 
 func dataSourceCloudProviderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	cloudProviderId := d.Get("id").(string)
+	if cloudProviderId == "" {
+		cloudProviders, err := listCloudProviders(c, organizationId, newLookupFilter(d, "name"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, cloudProvider := range cloudProviders {
+			ids = append(ids, cloudProvider.ID)
+		}
+
+		cloudProviderId, err = lookupId("cloud provider", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	cloudProvider, err := c.GetCloudProvider(&organizationId, &cloudProviderId)
 	if err != nil {
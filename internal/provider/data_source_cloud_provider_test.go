@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionCloudProviderDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_cloud_provider.test"
+	dataSourceName := "data.xilution_cloud_provider.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccCloudProviderConfig("AWS") + `
+data "xilution_cloud_provider" "test" {
+  id              = xilution_cloud_provider.test.id
+  organization_id = xilution_cloud_provider.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "cloud_provider", resourceName, "cloud_provider"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "account_id", resourceName, "account_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "region", resourceName, "region"),
+				),
+			},
+		},
+	})
+}
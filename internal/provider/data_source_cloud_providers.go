@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceCloudProviders() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudProvidersRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"cloud_provider": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(cloudProviders, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"cloud_providers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceCloudProvider().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceCloudProvidersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "cloud_provider", "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cloudProviders, err := listCloudProviders(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	cloudProviderMaps := []interface{}{}
+	for _, cloudProvider := range cloudProviders {
+		ids = append(ids, cloudProvider.ID)
+		cloudProviderMaps = append(cloudProviderMaps, flattenCloudProvider(cloudProvider))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("cloud_providers", cloudProviderMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listCloudProviders pages through an organization's cloud providers and
+// returns the ones that pass the filter.
+func listCloudProviders(c xilutionClient, organizationId string, filter *listFilter) ([]xc.CloudProvider, error) {
+	cloudProviders := []xc.CloudProvider{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetCloudProviders(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cloudProvider := range response.Content {
+			if filter.matches(cloudProvider.Name, map[string]string{
+				"cloud_provider": cloudProvider.Provider,
+				"owning_user_id": cloudProvider.OwningUserId,
+				"status":         cloudProvider.Status,
+			}) {
+				cloudProviders = append(cloudProviders, cloudProvider)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return cloudProviders, nil
+}
+
+func flattenCloudProvider(cloudProvider xc.CloudProvider) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              cloudProvider.ID,
+		"name":            cloudProvider.Name,
+		"cloud_provider":  cloudProvider.Provider,
+		"account_id":      cloudProvider.AccountId,
+		"region":          cloudProvider.Region,
+		"organization_id": cloudProvider.OrganizationId,
+		"owning_user_id":  cloudProvider.OwningUserId,
+		"created_at":      cloudProvider.CreatedAt,
+		"modified_at":     cloudProvider.ModifiedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionCloudProvidersDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_cloud_provider.test"
+	dataSourceName := "data.xilution_cloud_providers.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccCloudProviderConfig("AWS") + `
+data "xilution_cloud_providers" "test" {
+  name_regex = "^${xilution_cloud_provider.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "cloud_providers.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "cloud_providers.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
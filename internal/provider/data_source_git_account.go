@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceGitAccount() *schema.Resource {
@@ -13,12 +12,16 @@ func dataSourceGitAccount() *schema.Resource {
 		ReadContext: dataSourceGitAccountRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"git_provider": {
 				Type:     schema.TypeString,
@@ -26,7 +29,8 @@ func dataSourceGitAccount() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -45,12 +49,31 @@ func dataSourceGitAccount() *schema.Resource {
 }
 
 func dataSourceGitAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	gitAccountId := d.Get("id").(string)
+	if gitAccountId == "" {
+		gitAccounts, err := listGitAccounts(c, organizationId, newLookupFilter(d, "name"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, gitAccount := range gitAccounts {
+			ids = append(ids, gitAccount.ID)
+		}
+
+		gitAccountId, err = lookupId("git account", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	gitAccount, err := c.GetGitAccount(&organizationId, &gitAccountId)
 	if err != nil {
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionGitAccountDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_account.test"
+	dataSourceName := "data.xilution_git_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitAccountConfig("xilution") + `
+data "xilution_git_account" "test" {
+  id              = xilution_git_account.test.id
+  organization_id = xilution_git_account.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_provider", resourceName, "git_provider"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "owning_user_id", resourceName, "owning_user_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "created_at", resourceName, "created_at"),
+				),
+			},
+		},
+	})
+}
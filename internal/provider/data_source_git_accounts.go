@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceGitAccounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGitAccountsRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"git_accounts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceGitAccount().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceGitAccountsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gitAccounts, err := listGitAccounts(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	gitAccountMaps := []interface{}{}
+	for _, gitAccount := range gitAccounts {
+		ids = append(ids, gitAccount.ID)
+		gitAccountMaps = append(gitAccountMaps, flattenGitAccount(gitAccount))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("git_accounts", gitAccountMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listGitAccounts pages through an organization's git accounts and returns the
+// ones that pass the filter.
+func listGitAccounts(c xilutionClient, organizationId string, filter *listFilter) ([]xc.GitAccount, error) {
+	gitAccounts := []xc.GitAccount{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetGitAccounts(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, gitAccount := range response.Content {
+			if filter.matches(gitAccount.Name, map[string]string{
+				"owning_user_id": gitAccount.OwningUserId,
+				"status":         gitAccount.Status,
+			}) {
+				gitAccounts = append(gitAccounts, gitAccount)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return gitAccounts, nil
+}
+
+func flattenGitAccount(gitAccount xc.GitAccount) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              gitAccount.ID,
+		"name":            gitAccount.Name,
+		"git_provider":    gitAccount.Provider,
+		"organization_id": gitAccount.OrganizationId,
+		"owning_user_id":  gitAccount.OwningUserId,
+		"created_at":      gitAccount.CreatedAt,
+		"modified_at":     gitAccount.ModifiedAt,
+	}
+}
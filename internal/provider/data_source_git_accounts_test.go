@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionGitAccountsDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_account.test"
+	dataSourceName := "data.xilution_git_accounts.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitAccountConfig("xilution") + `
+data "xilution_git_accounts" "test" {
+  name_regex = "^${xilution_git_account.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_accounts.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_accounts.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceGitRepo() *schema.Resource {
@@ -13,20 +12,27 @@ func dataSourceGitRepo() *schema.Resource {
 		ReadContext: dataSourceGitRepoRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"git_account_id": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -45,12 +51,31 @@ func dataSourceGitRepo() *schema.Resource {
 }
 
 func dataSourceGitRepoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	gitRepoId := d.Get("id").(string)
+	if gitRepoId == "" {
+		gitRepos, err := listGitRepos(c, organizationId, newLookupFilter(d, "name", "git_account_id"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, gitRepo := range gitRepos {
+			ids = append(ids, gitRepo.ID)
+		}
+
+		gitRepoId, err = lookupId("git repo", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	gitRepo, err := c.GetGitRepo(&organizationId, &gitRepoId)
 	if err != nil {
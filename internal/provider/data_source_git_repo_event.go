@@ -6,7 +6,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceGitRepoEvent() *schema.Resource {
@@ -27,12 +26,18 @@ func dataSourceGitRepoEvent() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"event_type": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"create_repo_from_template": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(createRepoFromTemplateResource().Schema),
+			},
 			"parameters": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -54,11 +59,14 @@ func dataSourceGitRepoEvent() *schema.Resource {
 }
 
 func dataSourceGitRepoEventRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	gitRepoEventId := d.Get("id").(string)
 
 	gitRepoEvent, err := c.GetGitRepoEvent(&organizationId, &gitRepoEventId)
@@ -94,6 +102,14 @@ func dataSourceGitRepoEventRead(ctx context.Context, d *schema.ResourceData, m i
 		return diag.FromErr(err)
 	}
 
+	createRepoFromTemplate, err := flattenCreateRepoFromTemplate(gitRepoEvent)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("create_repo_from_template", createRepoFromTemplate); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("owning_user_id", gitRepoEvent.OwningUserId); err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionGitRepoEventDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_repo_event.test"
+	dataSourceName := "data.xilution_git_repo_event.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitRepoEventConfig() + `
+data "xilution_git_repo_event" "test" {
+  id              = xilution_git_repo_event.test.id
+  organization_id = xilution_git_repo_event.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_account_id", resourceName, "git_account_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_repo_id", resourceName, "git_repo_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "event_type", resourceName, "event_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "parameters", resourceName, "parameters"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceGitRepoEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGitRepoEventsRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"git_account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"git_repo_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"event_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(gitRepoEventTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"git_repo_events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceGitRepoEvent().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceGitRepoEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "git_account_id", "git_repo_id", "event_type", "owning_user_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gitRepoEvents, err := listGitRepoEvents(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	gitRepoEventMaps := []interface{}{}
+	for _, gitRepoEvent := range gitRepoEvents {
+		gitRepoEventMap, err := flattenGitRepoEvent(gitRepoEvent)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids = append(ids, gitRepoEvent.ID)
+		gitRepoEventMaps = append(gitRepoEventMaps, gitRepoEventMap)
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("git_repo_events", gitRepoEventMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listGitRepoEvents pages through an organization's git repo events and
+// returns the ones that pass the filter.
+func listGitRepoEvents(c xilutionClient, organizationId string, filter *listFilter) ([]xc.GitRepoEvent, error) {
+	gitRepoEvents := []xc.GitRepoEvent{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetGitRepoEvents(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, gitRepoEvent := range response.Content {
+			if filter.matches("", map[string]string{
+				"git_account_id": gitRepoEvent.GitAccountId,
+				"git_repo_id":    gitRepoEvent.GitRepoId,
+				"event_type":     gitRepoEvent.EventType,
+				"owning_user_id": gitRepoEvent.OwningUserId,
+			}) {
+				gitRepoEvents = append(gitRepoEvents, gitRepoEvent)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return gitRepoEvents, nil
+}
+
+func flattenGitRepoEvent(gitRepoEvent xc.GitRepoEvent) (map[string]interface{}, error) {
+	parameters, err := json.Marshal(gitRepoEvent.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	createRepoFromTemplate, err := flattenCreateRepoFromTemplate(&gitRepoEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"id":                        gitRepoEvent.ID,
+		"git_account_id":            gitRepoEvent.GitAccountId,
+		"git_repo_id":               gitRepoEvent.GitRepoId,
+		"organization_id":           gitRepoEvent.OrganizationId,
+		"event_type":                gitRepoEvent.EventType,
+		"create_repo_from_template": createRepoFromTemplate,
+		"parameters":                string(parameters),
+		"owning_user_id":            gitRepoEvent.OwningUserId,
+		"created_at":                gitRepoEvent.CreatedAt,
+		"modified_at":               gitRepoEvent.ModifiedAt,
+	}, nil
+}
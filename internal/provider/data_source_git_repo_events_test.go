@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestDataSourceGitRepoEvents_filters(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.gitRepoEvents["git-repo-event-1"] = &xc.GitRepoEvent{
+		ID:             "git-repo-event-1",
+		GitAccountId:   "git-account-1",
+		GitRepoId:      "git-repo-1",
+		EventType:      "CREATE_REPO_FROM_TEMPLATE_REPO",
+		OrganizationId: "org-1",
+		OwningUserId:   "user-1",
+		Parameters:     map[string]interface{}{"sourceOwner": "xilution", "sourceRepo": "xilution-bison-poc-template"},
+	}
+	c.gitRepoEvents["git-repo-event-2"] = &xc.GitRepoEvent{
+		ID:             "git-repo-event-2",
+		GitAccountId:   "git-account-1",
+		GitRepoId:      "git-repo-2",
+		EventType:      "CREATE_REPO_FROM_TEMPLATE_REPO",
+		OrganizationId: "org-1",
+		OwningUserId:   "user-1",
+	}
+
+	state, diags := newTestResource(t, dataSourceGitRepoEvents(), testProviderMeta(c)).readData(map[string]interface{}{
+		"organization_id": "org-1",
+		"git_repo_id":     "git-repo-1",
+	})
+	if diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	if state.Attributes["ids.#"] != "1" || state.Attributes["ids.0"] != "git-repo-event-1" {
+		t.Fatalf("unexpected ids: %v", state.Attributes)
+	}
+	if state.Attributes["git_repo_events.0.create_repo_from_template.0.source_repo"] != "xilution-bison-poc-template" {
+		t.Fatalf("unexpected git repo event: %v", state.Attributes)
+	}
+}
+
+func TestAccXilutionGitRepoEventsDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_repo_event.test"
+	dataSourceName := "data.xilution_git_repo_events.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitRepoEventConfig() + `
+data "xilution_git_repo_events" "test" {
+  git_repo_id = xilution_git_repo_event.test.git_repo_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_repo_events.0.event_type", resourceName, "event_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_repo_events.0.create_repo_from_template.0.source_repo", resourceName, "create_repo_from_template.0.source_repo"),
+				),
+			},
+		},
+	})
+}
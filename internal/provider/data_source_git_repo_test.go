@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionGitRepoDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_repo.test"
+	dataSourceName := "data.xilution_git_repo.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitRepoConfig("website") + `
+data "xilution_git_repo" "test" {
+  id              = xilution_git_repo.test.id
+  organization_id = xilution_git_repo.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_account_id", resourceName, "git_account_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "owning_user_id", resourceName, "owning_user_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "created_at", resourceName, "created_at"),
+				),
+			},
+		},
+	})
+}
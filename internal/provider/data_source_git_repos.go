@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceGitRepos() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGitReposRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"git_account_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"git_repos": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceGitRepo().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceGitReposRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "git_account_id", "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	gitRepos, err := listGitRepos(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	gitRepoMaps := []interface{}{}
+	for _, gitRepo := range gitRepos {
+		ids = append(ids, gitRepo.ID)
+		gitRepoMaps = append(gitRepoMaps, flattenGitRepo(gitRepo))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("git_repos", gitRepoMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listGitRepos pages through an organization's git repos and returns the ones
+// that pass the filter.
+func listGitRepos(c xilutionClient, organizationId string, filter *listFilter) ([]xc.GitRepo, error) {
+	gitRepos := []xc.GitRepo{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetGitRepos(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, gitRepo := range response.Content {
+			if filter.matches(gitRepo.Name, map[string]string{
+				"git_account_id": gitRepo.GitAccountId,
+				"owning_user_id": gitRepo.OwningUserId,
+				"status":         gitRepo.Status,
+			}) {
+				gitRepos = append(gitRepos, gitRepo)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return gitRepos, nil
+}
+
+func flattenGitRepo(gitRepo xc.GitRepo) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              gitRepo.ID,
+		"name":            gitRepo.Name,
+		"git_account_id":  gitRepo.GitAccountId,
+		"organization_id": gitRepo.OrganizationId,
+		"owning_user_id":  gitRepo.OwningUserId,
+		"created_at":      gitRepo.CreatedAt,
+		"modified_at":     gitRepo.ModifiedAt,
+	}
+}
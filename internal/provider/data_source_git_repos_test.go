@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestDataSourceGitRepos_filters(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.gitRepos["git-repo-1"] = &xc.GitRepo{ID: "git-repo-1", Name: "website", GitAccountId: "git-account-1", OrganizationId: "org-1", OwningUserId: "user-1", Status: ACTIVE}
+	c.gitRepos["git-repo-2"] = &xc.GitRepo{ID: "git-repo-2", Name: "api", GitAccountId: "git-account-1", OrganizationId: "org-1", OwningUserId: "user-1"}
+	c.gitRepos["git-repo-3"] = &xc.GitRepo{ID: "git-repo-3", Name: "website", GitAccountId: "git-account-2", OrganizationId: "org-1", OwningUserId: "user-1", Status: ACTIVE}
+
+	state, diags := newTestResource(t, dataSourceGitRepos(), testProviderMeta(c)).readData(map[string]interface{}{
+		"organization_id": "org-1",
+		"git_account_id":  "git-account-1",
+		"status":          ACTIVE,
+	})
+	if diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	if state.Attributes["ids.#"] != "1" || state.Attributes["ids.0"] != "git-repo-1" {
+		t.Fatalf("unexpected ids: %v", state.Attributes)
+	}
+	if state.Attributes["git_repos.0.name"] != "website" {
+		t.Fatalf("unexpected git repo: %v", state.Attributes)
+	}
+}
+
+func TestAccXilutionGitReposDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_repo.test"
+	dataSourceName := "data.xilution_git_repos.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitRepoConfig("website") + `
+data "xilution_git_repos" "test" {
+  name_regex = "^${xilution_git_repo.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_repos.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_repos.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
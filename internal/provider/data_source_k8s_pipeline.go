@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceK8sPipeline() *schema.Resource {
@@ -13,12 +12,16 @@ func dataSourceK8sPipeline() *schema.Resource {
 		ReadContext: dataSourceK8sPipelineRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"pipeline_type": {
 				Type:     schema.TypeString,
@@ -30,7 +33,8 @@ func dataSourceK8sPipeline() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -44,17 +48,48 @@ func dataSourceK8sPipeline() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"infrastructure_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_up_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_down_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceK8sPipelineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	k8sPipelineId := d.Get("id").(string)
+	if k8sPipelineId == "" {
+		k8sPipelines, err := listK8sPipelines(c, organizationId, newLookupFilter(d, "name"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, k8sPipeline := range k8sPipelines {
+			ids = append(ids, k8sPipeline.ID)
+		}
+
+		k8sPipelineId, err = lookupId("k8s pipeline", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	k8sPipeline, err := c.GetK8sPipeline(&organizationId, &k8sPipelineId)
 	if err != nil {
@@ -93,6 +128,10 @@ func dataSourceK8sPipelineRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	if err := setPipelineStatus(d, k8sPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(k8sPipeline.ID)
 
 	return diags
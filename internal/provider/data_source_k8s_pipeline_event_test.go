@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionK8sPipelineEventDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_k8s_pipeline_event.test"
+	dataSourceName := "data.xilution_k8s_pipeline_event.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccK8sPipelineEventConfig("PROVISION") + `
+data "xilution_k8s_pipeline_event" "test" {
+  id              = xilution_k8s_pipeline_event.test.id
+  organization_id = xilution_k8s_pipeline_event.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipeline_id", resourceName, "pipeline_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "event_type", resourceName, "event_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
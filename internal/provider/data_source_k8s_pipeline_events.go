@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceK8sPipelineEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceK8sPipelineEventsRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"pipeline_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"event_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineEventTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"k8s_pipeline_events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceK8sPipelineEvent().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceK8sPipelineEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "pipeline_id", "event_type", "owning_user_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	k8sPipelineEvents, err := listPipelineEvents(organizationId, filter, c.GetK8sPipelineEvents)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	k8sPipelineEventMaps := []interface{}{}
+	for _, k8sPipelineEvent := range k8sPipelineEvents {
+		ids = append(ids, k8sPipelineEvent.ID)
+		k8sPipelineEventMaps = append(k8sPipelineEventMaps, flattenPipelineEvent(k8sPipelineEvent))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("k8s_pipeline_events", k8sPipelineEventMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionK8sPipelineDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_k8s_pipeline.test"
+	dataSourceName := "data.xilution_k8s_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccK8sPipelineConfig("K8s") + `
+data "xilution_k8s_pipeline" "test" {
+  id              = xilution_k8s_pipeline.test.id
+  organization_id = xilution_k8s_pipeline.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipeline_type", resourceName, "pipeline_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_pipeline_id", resourceName, "vpc_pipeline_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceK8sPipelines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceK8sPipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"pipeline_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"k8s_pipelines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceK8sPipeline().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceK8sPipelinesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "pipeline_type", "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	k8sPipelines, err := listK8sPipelines(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	k8sPipelineMaps := []interface{}{}
+	for _, k8sPipeline := range k8sPipelines {
+		ids = append(ids, k8sPipeline.ID)
+		k8sPipelineMaps = append(k8sPipelineMaps, flattenK8sPipeline(k8sPipeline))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("k8s_pipelines", k8sPipelineMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listK8sPipelines pages through an organization's k8s pipelines and returns
+// the ones that pass the filter.
+func listK8sPipelines(c xilutionClient, organizationId string, filter *listFilter) ([]xc.K8sPipeline, error) {
+	k8sPipelines := []xc.K8sPipeline{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetK8sPipelines(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, k8sPipeline := range response.Content {
+			infrastructureStatus, _, _ := flattenPipelineStatus(k8sPipeline.Status)
+			if filter.matches(k8sPipeline.Name, map[string]string{
+				"pipeline_type":   k8sPipeline.PipelineType,
+				"vpc_pipeline_id": k8sPipeline.VpcPipelineId,
+				"owning_user_id":  k8sPipeline.OwningUserId,
+				"status":          infrastructureStatus,
+			}) {
+				k8sPipelines = append(k8sPipelines, k8sPipeline)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return k8sPipelines, nil
+}
+
+func flattenK8sPipeline(k8sPipeline xc.K8sPipeline) map[string]interface{} {
+	infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus := flattenPipelineStatus(k8sPipeline.Status)
+
+	return map[string]interface{}{
+		"id":                           k8sPipeline.ID,
+		"name":                         k8sPipeline.Name,
+		"pipeline_type":                k8sPipeline.PipelineType,
+		"vpc_pipeline_id":              k8sPipeline.VpcPipelineId,
+		"organization_id":              k8sPipeline.OrganizationId,
+		"owning_user_id":               k8sPipeline.OwningUserId,
+		"created_at":                   k8sPipeline.CreatedAt,
+		"modified_at":                  k8sPipeline.ModifiedAt,
+		"infrastructure_status":        infrastructureStatus,
+		"latest_up_execution_status":   latestUpExecutionStatus,
+		"latest_down_execution_status": latestDownExecutionStatus,
+	}
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionK8sPipelinesDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_k8s_pipeline.test"
+	dataSourceName := "data.xilution_k8s_pipelines.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccK8sPipelineConfig("K8s") + `
+data "xilution_k8s_pipelines" "test" {
+  name_regex = "^${xilution_k8s_pipeline.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "k8s_pipelines.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "k8s_pipelines.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
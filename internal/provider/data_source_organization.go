@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceOrganization() *schema.Resource {
@@ -14,7 +13,8 @@ func dataSourceOrganization() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"name": {
 				Type:     schema.TypeString,
@@ -65,11 +65,17 @@ func dataSourceOrganization() *schema.Resource {
 }
 
 func dataSourceOrganizationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
 	organizationId := d.Get("id").(string)
+	if organizationId == "" {
+		organizationId = m.(*providerMeta).organizationId
+	}
+	if organizationId == "" {
+		return diag.Errorf("id must be set on the data source or organization_id on the provider")
+	}
 
 	organization, err := c.GetOrganization(&organizationId)
 	if err != nil {
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestAccXilutionOrganizationDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	dataSourceName := "data.xilution_organization.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + fmt.Sprintf(`
+data "xilution_organization" "test" {
+  id = %q
+}
+`, mockOrganizationId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", mockOrganizationId),
+					resource.TestCheckResourceAttr(dataSourceName, "name", "Xilution"),
+					resource.TestCheckResourceAttr(dataSourceName, "active", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceOrganization_providerDefault(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.organizations["org-1"] = &xc.Organization{ID: "org-1", Name: "Xilution"}
+
+	meta := testProviderMeta(c)
+	meta.organizationId = "org-1"
+
+	state, diags := newTestResource(t, dataSourceOrganization(), meta).readData(map[string]interface{}{})
+	if diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	if state.ID != "org-1" || state.Attributes["name"] != "Xilution" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+
+	_, diags = newTestResource(t, dataSourceOrganization(), testProviderMeta(c)).readData(map[string]interface{}{})
+	if !diags.HasError() {
+		t.Fatal("expected an error without an id or a provider organization_id")
+	}
+}
@@ -2,11 +2,9 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourcePipelinePrototype() *schema.Resource {
@@ -14,12 +12,16 @@ func dataSourcePipelinePrototype() *schema.Resource {
 		ReadContext: dataSourcePipelinePrototypeRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"version": {
 				Type:     schema.TypeString,
@@ -33,21 +35,25 @@ func dataSourcePipelinePrototype() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
-			"references": {
-				Type:     schema.TypeString,
+			"reference": {
+				Type:     schema.TypeList,
 				Computed: true,
+				Elem:     dataSourceListElem(pipelinePrototypeReferenceResource().Schema),
 			},
-			"parameter_definitions": {
-				Type:     schema.TypeString,
+			"parameter_definition": {
+				Type:     schema.TypeList,
 				Computed: true,
+				Elem:     dataSourceListElem(pipelinePrototypeParameterDefinitionResource().Schema),
 			},
 			"terraform": {
-				Type:     schema.TypeString,
+				Type:     schema.TypeList,
 				Computed: true,
+				Elem:     dataSourceListElem(pipelinePrototypeTerraformResource().Schema),
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -66,12 +72,31 @@ func dataSourcePipelinePrototype() *schema.Resource {
 }
 
 func dataSourcePipelinePrototypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	id := d.Get("id").(string)
+	if id == "" {
+		pipelinePrototypes, err := listPipelinePrototypes(c, organizationId, newLookupFilter(d, "name"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, pipelinePrototype := range pipelinePrototypes {
+			ids = append(ids, pipelinePrototype.ID)
+		}
+
+		id, err = lookupId("pipeline prototype", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	pipelinePrototype, err := c.GetPipelinePrototype(&organizationId, &id)
 	if err != nil {
@@ -98,27 +123,19 @@ func dataSourcePipelinePrototypeRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	referencesData, err := json.Marshal(pipelinePrototype.References)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("references", string(referencesData)); err != nil {
+	if err := d.Set("reference", flattenPipelinePrototypeReferences(pipelinePrototype.References)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	parameterDefinitionsData, err := json.Marshal(pipelinePrototype.ParameterDefinitions)
+	parameterDefinitions, err := flattenPipelinePrototypeParameterDefinitions(pipelinePrototype.ParameterDefinitions)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("parameter_definitions", string(parameterDefinitionsData)); err != nil {
+	if err := d.Set("parameter_definition", parameterDefinitions); err != nil {
 		return diag.FromErr(err)
 	}
 
-	terraformData, err := json.Marshal(pipelinePrototype.Terraform)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("terraform", string(terraformData)); err != nil {
+	if err := d.Set("terraform", flattenPipelinePrototypeTerraform(pipelinePrototype.Terraform)); err != nil {
 		return diag.FromErr(err)
 	}
 
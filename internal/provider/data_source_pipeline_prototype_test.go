@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionPipelinePrototypeDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_pipeline_prototype.test"
+	dataSourceName := "data.xilution_pipeline_prototype.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccPipelinePrototypeConfig("1.0.0") + `
+data "xilution_pipeline_prototype" "test" {
+  id              = xilution_pipeline_prototype.test.id
+  organization_id = xilution_pipeline_prototype.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "version", resourceName, "version"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "active", resourceName, "active"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "terraform.0.sub_path", resourceName, "terraform.0.sub_path"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "parameter_definition.0.name", resourceName, "parameter_definition.0.name"),
+				),
+			},
+		},
+	})
+}
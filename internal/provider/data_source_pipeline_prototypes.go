@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourcePipelinePrototypes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePipelinePrototypesRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pipeline_prototypes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourcePipelinePrototype().Schema),
+			},
+		},
+	}
+}
+
+func dataSourcePipelinePrototypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "owning_user_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	pipelinePrototypes, err := listPipelinePrototypes(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	pipelinePrototypeMaps := []interface{}{}
+	for _, pipelinePrototype := range pipelinePrototypes {
+		pipelinePrototypeMap, err := flattenPipelinePrototype(pipelinePrototype)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids = append(ids, pipelinePrototype.ID)
+		pipelinePrototypeMaps = append(pipelinePrototypeMaps, pipelinePrototypeMap)
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("pipeline_prototypes", pipelinePrototypeMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listPipelinePrototypes pages through an organization's pipeline prototypes
+// and returns the ones that pass the filter.
+func listPipelinePrototypes(c xilutionClient, organizationId string, filter *listFilter) ([]xc.PipelinePrototype, error) {
+	pipelinePrototypes := []xc.PipelinePrototype{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetPipelinePrototypes(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pipelinePrototype := range response.Content {
+			if filter.matches(pipelinePrototype.Name, map[string]string{
+				"owning_user_id": pipelinePrototype.OwningUserId,
+			}) {
+				pipelinePrototypes = append(pipelinePrototypes, pipelinePrototype)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return pipelinePrototypes, nil
+}
+
+func flattenPipelinePrototype(pipelinePrototype xc.PipelinePrototype) (map[string]interface{}, error) {
+	parameterDefinitions, err := flattenPipelinePrototypeParameterDefinitions(pipelinePrototype.ParameterDefinitions)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"id":                   pipelinePrototype.ID,
+		"name":                 pipelinePrototype.Name,
+		"version":              pipelinePrototype.Version,
+		"description":          pipelinePrototype.Description,
+		"active":               pipelinePrototype.Active,
+		"reference":            flattenPipelinePrototypeReferences(pipelinePrototype.References),
+		"parameter_definition": parameterDefinitions,
+		"terraform":            flattenPipelinePrototypeTerraform(pipelinePrototype.Terraform),
+		"organization_id":      pipelinePrototype.OrganizationId,
+		"owning_user_id":       pipelinePrototype.OwningUserId,
+		"created_at":           pipelinePrototype.CreatedAt,
+		"modified_at":          pipelinePrototype.ModifiedAt,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionPipelinePrototypesDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_pipeline_prototype.test"
+	dataSourceName := "data.xilution_pipeline_prototypes.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccPipelinePrototypeConfig("1.0.0") + `
+data "xilution_pipeline_prototypes" "test" {
+  name_regex = "^${xilution_pipeline_prototype.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipeline_prototypes.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipeline_prototypes.0.version", resourceName, "version"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipeline_prototypes.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
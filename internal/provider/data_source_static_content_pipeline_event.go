@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceStaticContentPipelineEvent() *schema.Resource {
@@ -18,7 +17,8 @@ func dataSourceStaticContentPipelineEvent() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"pipeline_id": {
 				Type:     schema.TypeString,
@@ -45,11 +45,14 @@ func dataSourceStaticContentPipelineEvent() *schema.Resource {
 }
 
 func dataSourceStaticContentPipelineEventRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	pipelineEventId := d.Get("id").(string)
 
 	pipelineEvent, err := c.GetStaticContentPipelineEvent(&organizationId, &pipelineEventId)
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionStaticContentPipelineEventsDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_static_content_pipeline_event.test"
+	dataSourceName := "data.xilution_static_content_pipeline_events.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccStaticContentPipelineEventConfig("PROVISION") + `
+data "xilution_static_content_pipeline_events" "test" {
+  pipeline_id = xilution_static_content_pipeline_event.test.pipeline_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "static_content_pipeline_events.0.event_type", resourceName, "event_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "static_content_pipeline_events.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
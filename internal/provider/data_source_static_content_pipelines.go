@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceStaticContentPipelines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStaticContentPipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"pipeline_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"static_content_pipelines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceStaticContentPipeline().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceStaticContentPipelinesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "pipeline_type", "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	staticContentPipelines, err := listStaticContentPipelines(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	staticContentPipelineMaps := []interface{}{}
+	for _, staticContentPipeline := range staticContentPipelines {
+		ids = append(ids, staticContentPipeline.ID)
+		staticContentPipelineMaps = append(staticContentPipelineMaps, flattenStaticContentPipeline(staticContentPipeline))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("static_content_pipelines", staticContentPipelineMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listStaticContentPipelines pages through an organization's static content
+// pipelines and returns the ones that pass the filter.
+func listStaticContentPipelines(c xilutionClient, organizationId string, filter *listFilter) ([]xc.StaticContentPipeline, error) {
+	staticContentPipelines := []xc.StaticContentPipeline{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetStaticContentPipelines(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, staticContentPipeline := range response.Content {
+			infrastructureStatus, _, _ := flattenPipelineStatus(staticContentPipeline.Status)
+			if filter.matches(staticContentPipeline.Name, map[string]string{
+				"pipeline_type":  staticContentPipeline.PipelineType,
+				"owning_user_id": staticContentPipeline.OwningUserId,
+				"status":         infrastructureStatus,
+			}) {
+				staticContentPipelines = append(staticContentPipelines, staticContentPipeline)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return staticContentPipelines, nil
+}
+
+func flattenStaticContentPipeline(staticContentPipeline xc.StaticContentPipeline) map[string]interface{} {
+	infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus := flattenPipelineStatus(staticContentPipeline.Status)
+
+	stages := make([]interface{}, len(staticContentPipeline.Stages))
+	for i, stage := range staticContentPipeline.Stages {
+		stages[i] = map[string]interface{}{
+			"name": stage.Name,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                           staticContentPipeline.ID,
+		"name":                         staticContentPipeline.Name,
+		"pipeline_type":                staticContentPipeline.PipelineType,
+		"cloud_provider_id":            staticContentPipeline.CloudProviderId,
+		"git_repo_id":                  staticContentPipeline.GitRepoId,
+		"stages":                       stages,
+		"organization_id":              staticContentPipeline.OrganizationId,
+		"owning_user_id":               staticContentPipeline.OwningUserId,
+		"created_at":                   staticContentPipeline.CreatedAt,
+		"modified_at":                  staticContentPipeline.ModifiedAt,
+		"infrastructure_status":        infrastructureStatus,
+		"latest_up_execution_status":   latestUpExecutionStatus,
+		"latest_down_execution_status": latestDownExecutionStatus,
+	}
+}
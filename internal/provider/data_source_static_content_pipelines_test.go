@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionStaticContentPipelinesDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_static_content_pipeline.test"
+	dataSourceName := "data.xilution_static_content_pipelines.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccStaticContentPipelineConfig("Static Content", "master") + `
+data "xilution_static_content_pipelines" "test" {
+  name_regex = "^${xilution_static_content_pipeline.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "static_content_pipelines.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "static_content_pipelines.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceUser() *schema.Resource {
@@ -13,8 +12,10 @@ func dataSourceUser() *schema.Resource {
 		ReadContext: dataSourceUserRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username"},
 			},
 			"first_name": {
 				Type:     schema.TypeString,
@@ -29,12 +30,15 @@ func dataSourceUser() *schema.Resource {
 				Computed: true,
 			},
 			"username": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "username"},
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -57,12 +61,31 @@ func dataSourceUser() *schema.Resource {
 }
 
 func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	userId := d.Get("id").(string)
+	if userId == "" {
+		users, err := listUsers(c, organizationId, newLookupFilter(d, "username"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, user := range users {
+			ids = append(ids, user.ID)
+		}
+
+		userId, err = lookupId("user", d.Get("username").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	user, err := c.GetUser(&organizationId, &userId)
 	if err != nil {
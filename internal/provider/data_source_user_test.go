@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestAccXilutionUserDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	dataSourceName := "data.xilution_user.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + fmt.Sprintf(`
+data "xilution_user" "test" {
+  id              = %q
+  organization_id = %q
+}
+`, mockUserId, mockOrganizationId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "first_name", "Test"),
+					resource.TestCheckResourceAttr(dataSourceName, "email", "test@example.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "active", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceUser_lookupByUsername(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.users["user-1"] = &xc.User{ID: "user-1", Username: "tbrunia", Email: "tbrunia@example.com", OrganizationId: "org-1"}
+	c.users["user-2"] = &xc.User{ID: "user-2", Username: "someone", Email: "someone@example.com", OrganizationId: "org-1"}
+
+	state, diags := newTestResource(t, dataSourceUser(), testProviderMeta(c)).readData(map[string]interface{}{
+		"organization_id": "org-1",
+		"username":        "tbrunia",
+	})
+	if diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	if state.ID != "user-1" || state.Attributes["email"] != "tbrunia@example.com" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUsersRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceUser().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	users, err := listUsers(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	userMaps := []interface{}{}
+	for _, user := range users {
+		ids = append(ids, user.ID)
+		userMaps = append(userMaps, flattenUser(user))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("users", userMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listUsers pages through an organization's users and returns the ones that
+// pass the filter.
+func listUsers(c xilutionClient, organizationId string, filter *listFilter) ([]xc.User, error) {
+	users := []xc.User{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetUsers(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range response.Content {
+			if filter.matches(user.Username, nil) {
+				users = append(users, user)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return users, nil
+}
+
+func flattenUser(user xc.User) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              user.ID,
+		"first_name":      user.FirstName,
+		"last_name":       user.LastName,
+		"username":        user.Username,
+		"email":           user.Email,
+		"organization_id": user.OrganizationId,
+		"owning_user_id":  user.OwningUserId,
+		"active":          user.Active,
+		"created_at":      user.CreatedAt,
+		"modified_at":     user.ModifiedAt,
+	}
+}
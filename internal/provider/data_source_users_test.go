@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionUsersDataSource_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	dataSourceName := "data.xilution_users.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+data "xilution_users" "test" {
+  name_regex = "^test$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "ids.0", mockUserId),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.email", "test@example.com"),
+					resource.TestCheckResourceAttr(dataSourceName, "users.0.first_name", "Test"),
+				),
+			},
+		},
+	})
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	xc "github.com/xilution/xilution-client-go"
 )
 
 func dataSourceVpcPipeline() *schema.Resource {
@@ -13,24 +12,31 @@ func dataSourceVpcPipeline() *schema.Resource {
 		ReadContext: dataSourceVpcPipelineRead,
 		Schema: map[string]*schema.Schema{
 			"id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
 			},
 			"pipeline_type": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 			"cloud_provider_id": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
@@ -44,17 +50,48 @@ func dataSourceVpcPipeline() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"infrastructure_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_up_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_down_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func dataSourceVpcPipelineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	vpcPipelineId := d.Get("id").(string)
+	if vpcPipelineId == "" {
+		vpcPipelines, err := listVpcPipelines(c, organizationId, newLookupFilter(d, "name", "cloud_provider_id"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		ids := []string{}
+		for _, vpcPipeline := range vpcPipelines {
+			ids = append(ids, vpcPipeline.ID)
+		}
+
+		vpcPipelineId, err = lookupId("vpc pipeline", d.Get("name").(string), ids)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	vpcPipeline, err := c.GetVpcPipeline(&organizationId, &vpcPipelineId)
 	if err != nil {
@@ -93,6 +130,10 @@ func dataSourceVpcPipelineRead(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	if err := setPipelineStatus(d, vpcPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
 	d.SetId(vpcPipeline.ID)
 
 	return diags
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestDataSourceVpcPipelineEvents_filters(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	vpcPipelineId := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).apply(testVpcPipelineConfig("VPC 1")).ID
+	k8sPipelineId := newTestResource(t, resourceK8sPipeline(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "K8S 1",
+		"pipeline_type":   "AWS_SMALL",
+		"vpc_pipeline_id": vpcPipelineId,
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	}).ID
+
+	organizationId := "org-1"
+	ids := []string{}
+	for _, event := range []xc.PipelineEvent{
+		{PipelineId: vpcPipelineId, EventType: "PROVISION", OwningUserId: "user-1"},
+		{PipelineId: vpcPipelineId, EventType: "DEPROVISION", OwningUserId: "user-1"},
+		{PipelineId: k8sPipelineId, EventType: "PROVISION", OwningUserId: "user-1"},
+	} {
+		var location *string
+		var err error
+		if event.PipelineId == vpcPipelineId {
+			location, err = c.CreateVpcPipelineEvent(&organizationId, &event)
+		} else {
+			location, err = c.CreateK8sPipelineEvent(&organizationId, &event)
+		}
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		ids = append(ids, *getIdFromLocationUrl(location))
+	}
+
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected []string
+	}{
+		{"all", map[string]interface{}{}, ids[:2]},
+		{"event_type", map[string]interface{}{"event_type": "DEPROVISION"}, ids[1:2]},
+		{"pipeline_id", map[string]interface{}{"pipeline_id": k8sPipelineId}, []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.raw["organization_id"] = organizationId
+
+			state, diags := newTestResource(t, dataSourceVpcPipelineEvents(), testProviderMeta(c)).readData(tc.raw)
+			if diags.HasError() {
+				t.Fatalf("read: %v", diags)
+			}
+
+			actual := []string{}
+			for i := 0; i < len(tc.expected); i++ {
+				actual = append(actual, state.Attributes[fmt.Sprintf("ids.%d", i)])
+			}
+			if state.Attributes["ids.#"] != fmt.Sprint(len(tc.expected)) || !reflect.DeepEqual(actual, tc.expected) {
+				t.Fatalf("expected vpc pipeline events %v, got %v", tc.expected, state.Attributes)
+			}
+		})
+	}
+}
+
+func TestAccXilutionVpcPipelineEventsDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_vpc_pipeline_event.test"
+	dataSourceName := "data.xilution_vpc_pipeline_events.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineEventConfig("PROVISION") + `
+data "xilution_vpc_pipeline_events" "test" {
+  pipeline_id = xilution_vpc_pipeline_event.test.pipeline_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_pipeline_events.0.event_type", resourceName, "event_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_pipeline_events.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestAccXilutionVpcPipelineDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_vpc_pipeline.test"
+	dataSourceName := "data.xilution_vpc_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineConfig("VPC") + `
+data "xilution_vpc_pipeline" "test" {
+  id              = xilution_vpc_pipeline.test.id
+  organization_id = xilution_vpc_pipeline.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipeline_type", resourceName, "pipeline_type"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "cloud_provider_id", resourceName, "cloud_provider_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceVpcPipeline_lookupByName(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.vpcPipelines["vpc-pipeline-1"] = &xc.VpcPipeline{ID: "vpc-pipeline-1", Name: "VPC 1", PipelineType: "AWS_SMALL", CloudProviderId: "cloud-provider-1", OrganizationId: "org-1", OwningUserId: "user-1"}
+	c.vpcPipelines["vpc-pipeline-2"] = &xc.VpcPipeline{ID: "vpc-pipeline-2", Name: "VPC 2", PipelineType: "AWS_SMALL", CloudProviderId: "cloud-provider-1", OrganizationId: "org-1", OwningUserId: "user-1"}
+	c.vpcPipelines["vpc-pipeline-3"] = &xc.VpcPipeline{ID: "vpc-pipeline-3", Name: "VPC 2", PipelineType: "AWS_SMALL", CloudProviderId: "cloud-provider-2", OrganizationId: "org-1", OwningUserId: "user-1"}
+
+	cases := []struct {
+		name       string
+		raw        map[string]interface{}
+		expectedId string
+		expected   string
+	}{
+		{"unique", map[string]interface{}{"name": "VPC 1"}, "vpc-pipeline-1", ""},
+		{"narrowed", map[string]interface{}{"name": "VPC 2", "cloud_provider_id": "cloud-provider-2"}, "vpc-pipeline-3", ""},
+		{"missing", map[string]interface{}{"name": "VPC 3"}, "", `no vpc pipeline named "VPC 3" found`},
+		{"ambiguous", map[string]interface{}{"name": "VPC 2"}, "", `2 vpc pipelines named "VPC 2" found (vpc-pipeline-2, vpc-pipeline-3), set id to choose one`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.raw["organization_id"] = "org-1"
+
+			state, diags := newTestResource(t, dataSourceVpcPipeline(), testProviderMeta(c)).readData(tc.raw)
+			if tc.expected != "" {
+				if !diags.HasError() || diags[0].Summary != tc.expected {
+					t.Fatalf("expected error %q, got %v", tc.expected, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("read: %v", diags)
+			}
+
+			if state.ID != tc.expectedId || state.Attributes["name"] != tc.raw["name"] {
+				t.Fatalf("unexpected state: %v", state.Attributes)
+			}
+		})
+	}
+}
+
+func TestDataSourceVpcPipeline_lookupKeys(t *testing.T) {
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected string
+	}{
+		{"neither", map[string]interface{}{}, "one of `id,name` must be specified"},
+		{"both", map[string]interface{}{"id": "vpc-pipeline-1", "name": "VPC 1"}, "only one of `id,name` can be specified"},
+		{"qualified id", map[string]interface{}{"id": "vpc-pipeline-1", "cloud_provider_id": "cloud-provider-1"}, `"cloud_provider_id": conflicts with id`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := dataSourceVpcPipeline().Validate(terraform.NewResourceConfigRaw(tc.raw))
+			if !diags.HasError() || !strings.Contains(diags[0].Summary+diags[0].Detail, tc.expected) {
+				t.Fatalf("expected error containing %q, got %v", tc.expected, diags)
+			}
+		})
+	}
+}
+
+func TestAccXilutionVpcPipelineDataSource_name(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_vpc_pipeline.test"
+	dataSourceName := "data.xilution_vpc_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineConfig("VPC") + `
+data "xilution_vpc_pipeline" "test" {
+  name              = xilution_vpc_pipeline.test.name
+  cloud_provider_id = xilution_vpc_pipeline.test.cloud_provider_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "pipeline_type", resourceName, "pipeline_type"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceVpcPipelines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVpcPipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"pipeline_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"vpc_pipelines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceVpcPipeline().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceVpcPipelinesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "pipeline_type", "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vpcPipelines, err := listVpcPipelines(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	vpcPipelineMaps := []interface{}{}
+	for _, vpcPipeline := range vpcPipelines {
+		ids = append(ids, vpcPipeline.ID)
+		vpcPipelineMaps = append(vpcPipelineMaps, flattenVpcPipeline(vpcPipeline))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("vpc_pipelines", vpcPipelineMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listVpcPipelines pages through an organization's vpc pipelines and returns
+// the ones that pass the filter.
+func listVpcPipelines(c xilutionClient, organizationId string, filter *listFilter) ([]xc.VpcPipeline, error) {
+	vpcPipelines := []xc.VpcPipeline{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetVpcPipelines(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vpcPipeline := range response.Content {
+			infrastructureStatus, _, _ := flattenPipelineStatus(vpcPipeline.Status)
+			if filter.matches(vpcPipeline.Name, map[string]string{
+				"pipeline_type":     vpcPipeline.PipelineType,
+				"cloud_provider_id": vpcPipeline.CloudProviderId,
+				"owning_user_id":    vpcPipeline.OwningUserId,
+				"status":            infrastructureStatus,
+			}) {
+				vpcPipelines = append(vpcPipelines, vpcPipeline)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return vpcPipelines, nil
+}
+
+func flattenVpcPipeline(vpcPipeline xc.VpcPipeline) map[string]interface{} {
+	infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus := flattenPipelineStatus(vpcPipeline.Status)
+
+	return map[string]interface{}{
+		"id":                           vpcPipeline.ID,
+		"name":                         vpcPipeline.Name,
+		"pipeline_type":                vpcPipeline.PipelineType,
+		"cloud_provider_id":            vpcPipeline.CloudProviderId,
+		"organization_id":              vpcPipeline.OrganizationId,
+		"owning_user_id":               vpcPipeline.OwningUserId,
+		"created_at":                   vpcPipeline.CreatedAt,
+		"modified_at":                  vpcPipeline.ModifiedAt,
+		"infrastructure_status":        infrastructureStatus,
+		"latest_up_execution_status":   latestUpExecutionStatus,
+		"latest_down_execution_status": latestDownExecutionStatus,
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestDataSourceVpcPipelines_filters(t *testing.T) {
+	c := newFakeXilutionClient()
+	for i := 0; i < 2*listPageSize+10; i++ {
+		id := fmt.Sprintf("vpc-pipeline-%03d", i)
+		owningUserId := "user-1"
+		if i%3 == 0 {
+			owningUserId = "user-2"
+		}
+		c.vpcPipelines[id] = &xc.VpcPipeline{
+			ID:             id,
+			Name:           fmt.Sprintf("VPC %03d", i),
+			PipelineType:   "AWS_SMALL",
+			OrganizationId: "org-1",
+			OwningUserId:   owningUserId,
+		}
+	}
+	c.pipelineStatuses["vpc-pipeline-007"] = []xc.PipelineStatus{fakeStatus(CREATE_COMPLETE, SUCCEEDED)}
+
+	cases := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected int
+	}{
+		{"all", map[string]interface{}{}, 2*listPageSize + 10},
+		{"name_regex", map[string]interface{}{"name_regex": "^VPC 20"}, 10},
+		{"pipeline_type", map[string]interface{}{"pipeline_type": "AWS_SMALL"}, 2*listPageSize + 10},
+		{"pipeline_type and name_regex", map[string]interface{}{"pipeline_type": "AWS_SMALL", "name_regex": "^VPC 20"}, 10},
+		{"pipeline_type and status", map[string]interface{}{"pipeline_type": "AWS_SMALL", "status": CREATE_COMPLETE}, 1},
+		{"owning_user_id", map[string]interface{}{"owning_user_id": "user-2"}, 70},
+		{"combined", map[string]interface{}{"name_regex": "^VPC 0", "pipeline_type": "AWS_SMALL", "owning_user_id": "user-1"}, 66},
+		{"status", map[string]interface{}{"status": CREATE_COMPLETE}, 1},
+		{"none", map[string]interface{}{"name_regex": "^Nothing"}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.raw["organization_id"] = "org-1"
+
+			state, diags := newTestResource(t, dataSourceVpcPipelines(), testProviderMeta(c)).readData(tc.raw)
+			if diags.HasError() {
+				t.Fatalf("read: %v", diags)
+			}
+
+			if state.Attributes["ids.#"] != fmt.Sprint(tc.expected) || state.Attributes["vpc_pipelines.#"] != fmt.Sprint(tc.expected) {
+				t.Fatalf("expected %d vpc pipelines, got %s", tc.expected, state.Attributes["ids.#"])
+			}
+		})
+	}
+}
+
+func TestDataSourceVpcPipelines_organizationIdFromProvider(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.vpcPipelines["vpc-pipeline-1"] = &xc.VpcPipeline{
+		ID:              "vpc-pipeline-1",
+		Name:            "VPC",
+		PipelineType:    "AWS_SMALL",
+		CloudProviderId: "cloud-provider-1",
+		OrganizationId:  "org-1",
+		OwningUserId:    "user-1",
+	}
+
+	meta := testProviderMeta(c)
+	meta.organizationId = "org-1"
+
+	state, diags := newTestResource(t, dataSourceVpcPipelines(), meta).readData(map[string]interface{}{})
+	if diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	expected := map[string]string{
+		"id":                                    "org-1",
+		"organization_id":                       "org-1",
+		"ids.0":                                 "vpc-pipeline-1",
+		"vpc_pipelines.0.name":                  "VPC",
+		"vpc_pipelines.0.cloud_provider_id":     "cloud-provider-1",
+		"vpc_pipelines.0.infrastructure_status": NOT_FOUND,
+		"vpc_pipelines.0.latest_up_execution_status": "",
+	}
+	for key, value := range expected {
+		if state.Attributes[key] != value {
+			t.Errorf("%s: expected %q, got %q", key, value, state.Attributes[key])
+		}
+	}
+}
+
+func TestAccXilutionVpcPipelinesDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_vpc_pipeline.test"
+	dataSourceName := "data.xilution_vpc_pipelines.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineConfig("VPC") + `
+data "xilution_vpc_pipelines" "test" {
+  name_regex = "^${xilution_vpc_pipeline.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_pipelines.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "vpc_pipelines.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionWordPressPipelineDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_word_press_pipeline.test"
+	dataSourceName := "data.xilution_word_press_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccWordPressPipelineConfig("Word Press", "master") + `
+data "xilution_word_press_pipeline" "test" {
+  id              = xilution_word_press_pipeline.test.id
+  organization_id = xilution_word_press_pipeline.test.organization_id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "git_repo_id", resourceName, "git_repo_id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "branch", resourceName, "branch"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "stages.#", resourceName, "stages.#"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "stages.1.name", resourceName, "stages.1.name"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func dataSourceWordPressPipelines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceWordPressPipelinesRead,
+		Schema: map[string]*schema.Schema{
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+			},
+			"pipeline_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineTypes, false)),
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"word_press_pipelines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceListElem(dataSourceWordPressPipeline().Schema),
+			},
+		},
+	}
+}
+
+func dataSourceWordPressPipelinesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
+	var diags diag.Diagnostics
+
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, err := newListFilter(d, "pipeline_type", "owning_user_id", "status")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	wordPressPipelines, err := listWordPressPipelines(c, organizationId, filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ids := []string{}
+	wordPressPipelineMaps := []interface{}{}
+	for _, wordPressPipeline := range wordPressPipelines {
+		ids = append(ids, wordPressPipeline.ID)
+		wordPressPipelineMaps = append(wordPressPipelineMaps, flattenWordPressPipeline(wordPressPipeline))
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("word_press_pipelines", wordPressPipelineMaps); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(organizationId)
+
+	return diags
+}
+
+// listWordPressPipelines pages through an organization's word press pipelines
+// and returns the ones that pass the filter.
+func listWordPressPipelines(c xilutionClient, organizationId string, filter *listFilter) ([]xc.WordPressPipeline, error) {
+	wordPressPipelines := []xc.WordPressPipeline{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := c.GetWordPressPipelines(&organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, wordPressPipeline := range response.Content {
+			infrastructureStatus, _, _ := flattenPipelineStatus(wordPressPipeline.Status)
+			if filter.matches(wordPressPipeline.Name, map[string]string{
+				"pipeline_type":   wordPressPipeline.PipelineType,
+				"k8s_pipeline_id": wordPressPipeline.K8sPipelineId,
+				"owning_user_id":  wordPressPipeline.OwningUserId,
+				"status":          infrastructureStatus,
+			}) {
+				wordPressPipelines = append(wordPressPipelines, wordPressPipeline)
+			}
+		}
+
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
+		}
+	}
+
+	return wordPressPipelines, nil
+}
+
+func flattenWordPressPipeline(wordPressPipeline xc.WordPressPipeline) map[string]interface{} {
+	infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus := flattenPipelineStatus(wordPressPipeline.Status)
+
+	stages := make([]interface{}, len(wordPressPipeline.Stages))
+	for i, stage := range wordPressPipeline.Stages {
+		stages[i] = map[string]interface{}{
+			"name": stage.Name,
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                           wordPressPipeline.ID,
+		"name":                         wordPressPipeline.Name,
+		"pipeline_type":                wordPressPipeline.PipelineType,
+		"git_repo_id":                  wordPressPipeline.GitRepoId,
+		"stages":                       stages,
+		"organization_id":              wordPressPipeline.OrganizationId,
+		"owning_user_id":               wordPressPipeline.OwningUserId,
+		"created_at":                   wordPressPipeline.CreatedAt,
+		"modified_at":                  wordPressPipeline.ModifiedAt,
+		"infrastructure_status":        infrastructureStatus,
+		"latest_up_execution_status":   latestUpExecutionStatus,
+		"latest_down_execution_status": latestDownExecutionStatus,
+	}
+}
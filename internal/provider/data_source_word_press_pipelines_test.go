@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccXilutionWordPressPipelinesDataSource_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_word_press_pipeline.test"
+	dataSourceName := "data.xilution_word_press_pipelines.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccWordPressPipelineConfig("Word Press", "master") + `
+data "xilution_word_press_pipelines" "test" {
+  name_regex = "^${xilution_word_press_pipeline.test.name}$"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "ids.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "ids.0", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "word_press_pipelines.0.name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "word_press_pipelines.0.owning_user_id", resourceName, "owning_user_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,1351 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	xc "github.com/xilution/xilution-client-go"
+)
+
+const fakeTimestamp = "2021-08-11T00:00:00.000Z"
+
+// fakeXilutionClient is an in-memory stand-in for the Xilution API. Pipeline
+// events drive each pipeline through the status transitions the real API
+// reports, advancing one step every time the pipeline is read.
+type fakeXilutionClient struct {
+	mu     sync.Mutex
+	nextId int
+
+	organizations          map[string]*xc.Organization
+	clients                map[string]*xc.Client
+	users                  map[string]*xc.User
+	gitAccounts            map[string]*xc.GitAccount
+	gitRepos               map[string]*xc.GitRepo
+	gitRepoEvents          map[string]*xc.GitRepoEvent
+	cloudProviders         map[string]*xc.CloudProvider
+	vpcPipelines           map[string]*xc.VpcPipeline
+	k8sPipelines           map[string]*xc.K8sPipeline
+	wordPressPipelines     map[string]*xc.WordPressPipeline
+	staticContentPipelines map[string]*xc.StaticContentPipeline
+	apiPipelines           map[string]*xc.ApiPipeline
+	pipelineEvents         map[string]*xc.PipelineEvent
+	pipelinePrototypes     map[string]*xc.PipelinePrototype
+
+	pipelineStatuses map[string][]xc.PipelineStatus
+	// pipelinesWithoutStatus are returned without a status, like a pipeline
+	// whose create failed before the API reported one.
+	pipelinesWithoutStatus map[string]bool
+	// failingPipelines report a failed pipeline up after any event other than
+	// DEPROVISION, like a pipeline whose provisioning broke.
+	failingPipelines map[string]bool
+}
+
+var _ xilutionClient = (*fakeXilutionClient)(nil)
+
+func newFakeXilutionClient() *fakeXilutionClient {
+	return &fakeXilutionClient{
+		organizations:          map[string]*xc.Organization{},
+		clients:                map[string]*xc.Client{},
+		users:                  map[string]*xc.User{},
+		gitAccounts:            map[string]*xc.GitAccount{},
+		gitRepos:               map[string]*xc.GitRepo{},
+		gitRepoEvents:          map[string]*xc.GitRepoEvent{},
+		cloudProviders:         map[string]*xc.CloudProvider{},
+		vpcPipelines:           map[string]*xc.VpcPipeline{},
+		k8sPipelines:           map[string]*xc.K8sPipeline{},
+		wordPressPipelines:     map[string]*xc.WordPressPipeline{},
+		staticContentPipelines: map[string]*xc.StaticContentPipeline{},
+		apiPipelines:           map[string]*xc.ApiPipeline{},
+		pipelineEvents:         map[string]*xc.PipelineEvent{},
+		pipelinePrototypes:     map[string]*xc.PipelinePrototype{},
+		pipelineStatuses:       map[string][]xc.PipelineStatus{},
+		pipelinesWithoutStatus: map[string]bool{},
+		failingPipelines:       map[string]bool{},
+	}
+}
+
+func (f *fakeXilutionClient) newId() string {
+	f.nextId = f.nextId + 1
+
+	return fmt.Sprintf("%032x", f.nextId)
+}
+
+func fakeLocation(organizationId *string, collection string, id string) *string {
+	location := fmt.Sprintf("https://fake.api.xilution.com/organizations/%s/%s/%s", *organizationId, collection, id)
+
+	return &location
+}
+
+func fakeNotFound(kind string, id *string) error {
+	return &notFoundError{
+		message: fmt.Sprintf("%s %s not found", kind, *id),
+	}
+}
+
+func fakeStatus(infrastructureStatus string, latestUpExecutionStatus string) xc.PipelineStatus {
+	return xc.PipelineStatus{
+		InfrastructureStatus: infrastructureStatus,
+		ContinuousIntegrationStatus: &xc.ContinuousIntegrationStatus{
+			LatestUpExecutionStatus: latestUpExecutionStatus,
+		},
+	}
+}
+
+// fakePipelineStatusTransitions lists the statuses a pipeline reports, in
+// order, after an event of the given type.
+func fakePipelineStatusTransitions(eventType string) ([]xc.PipelineStatus, error) {
+	switch eventType {
+	case "PROVISION":
+		return []xc.PipelineStatus{
+			fakeStatus("CREATE_IN_PROGRESS", ""),
+			fakeStatus(CREATE_COMPLETE, "IN_PROGRESS"),
+			fakeStatus(CREATE_COMPLETE, SUCCEEDED),
+		}, nil
+	case "RUN_NOW":
+		return []xc.PipelineStatus{
+			fakeStatus(CREATE_COMPLETE, "IN_PROGRESS"),
+			fakeStatus(CREATE_COMPLETE, SUCCEEDED),
+		}, nil
+	case "REPROVISION":
+		return []xc.PipelineStatus{
+			fakeStatus("UPDATE_IN_PROGRESS", SUCCEEDED),
+			fakeStatus(UPDATE_COMPLETE, SUCCEEDED),
+		}, nil
+	case "DEPROVISION":
+		return []xc.PipelineStatus{
+			fakeStatus("DELETE_IN_PROGRESS", ""),
+			fakeStatus(NOT_FOUND, ""),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported event type %s", eventType)
+}
+
+// pipelineStatus returns the current status of a pipeline and moves it one
+// step along its pending transitions.
+func (f *fakeXilutionClient) pipelineStatus(pipelineId string) *xc.PipelineStatus {
+	if f.pipelinesWithoutStatus[pipelineId] {
+		return nil
+	}
+
+	statuses := f.pipelineStatuses[pipelineId]
+	if len(statuses) == 0 {
+		status := fakeStatus(NOT_FOUND, "")
+		return &status
+	}
+
+	status := statuses[0]
+	if len(statuses) > 1 {
+		f.pipelineStatuses[pipelineId] = statuses[1:]
+	}
+
+	return &status
+}
+
+func (f *fakeXilutionClient) createPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent, pipelineExists bool) (*string, error) {
+	if !pipelineExists {
+		return nil, fakeNotFound("pipeline", &pipelineEvent.PipelineId)
+	}
+
+	transitions, err := fakePipelineStatusTransitions(pipelineEvent.EventType)
+	if err != nil {
+		return nil, err
+	}
+	if f.failingPipelines[pipelineEvent.PipelineId] && pipelineEvent.EventType != "DEPROVISION" {
+		transitions = []xc.PipelineStatus{fakeStatus(CREATE_COMPLETE, FAILED)}
+	}
+	f.pipelineStatuses[pipelineEvent.PipelineId] = transitions
+
+	event := *pipelineEvent
+	event.ID = f.newId()
+	event.CreatedAt = fakeTimestamp
+	event.ModifiedAt = fakeTimestamp
+	f.pipelineEvents[event.ID] = &event
+
+	return fakeLocation(organizationId, "pipeline-events", event.ID), nil
+}
+
+// sentDeprovisionEvents returns the ids of the pipelines sent DEPROVISION
+// events, in the order they were sent.
+func (f *fakeXilutionClient) sentDeprovisionEvents() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id, event := range f.pipelineEvents {
+		if event.EventType == "DEPROVISION" {
+			ids = append(ids, id)
+		}
+	}
+	ids = fakeSortedIds(ids)
+
+	pipelineIds := []string{}
+	for _, id := range ids {
+		pipelineIds = append(pipelineIds, f.pipelineEvents[id].PipelineId)
+	}
+
+	return pipelineIds
+}
+
+// sentPipelineEvents returns the types of the events sent to a pipeline, in
+// the order they were sent.
+func (f *fakeXilutionClient) sentPipelineEvents(pipelineId string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id, event := range f.pipelineEvents {
+		if event.PipelineId == pipelineId {
+			ids = append(ids, id)
+		}
+	}
+	ids = fakeSortedIds(ids)
+
+	eventTypes := []string{}
+	for _, id := range ids {
+		eventTypes = append(eventTypes, f.pipelineEvents[id].EventType)
+	}
+
+	return eventTypes
+}
+
+func (f *fakeXilutionClient) getPipelineEvent(pipelineEventId *string) (*xc.PipelineEvent, error) {
+	event, ok := f.pipelineEvents[*pipelineEventId]
+	if !ok {
+		return nil, fakeNotFound("pipeline event", pipelineEventId)
+	}
+
+	found := *event
+
+	return &found, nil
+}
+
+// getPipelineEvents pages through the events of the pipelines of one kind.
+func (f *fakeXilutionClient) getPipelineEvents(pageSize, pageNumber *int, pipelineExists func(pipelineId string) bool) (*xc.FetchPipelineEventsResponse, error) {
+	ids := []string{}
+	for id, event := range f.pipelineEvents {
+		if pipelineExists(event.PipelineId) {
+			ids = append(ids, id)
+		}
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.PipelineEvent{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.pipelineEvents[id])
+	}
+
+	return &xc.FetchPipelineEventsResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) GetOrganization(organizationId *string) (*xc.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	organization, ok := f.organizations[*organizationId]
+	if !ok {
+		return nil, fakeNotFound("organization", organizationId)
+	}
+
+	found := *organization
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetClient(organizationId *string, clientId *string) (*xc.Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	client, ok := f.clients[*clientId]
+	if !ok {
+		return nil, fakeNotFound("client", clientId)
+	}
+
+	found := *client
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetClients(organizationId *string, pageSize, pageNumber *int) (*xc.FetchClientsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.clients {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.Client{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.clients[id])
+	}
+
+	return &xc.FetchClientsResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) GetUser(organizationId *string, userId *string) (*xc.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	user, ok := f.users[*userId]
+	if !ok {
+		return nil, fakeNotFound("user", userId)
+	}
+
+	found := *user
+
+	return &found, nil
+}
+
+// fakePage returns the bounds of the requested page over n elements and the
+// total number of pages, numbering pages from zero like the real API.
+func fakePage(n int, pageSize, pageNumber *int) (start, end, totalPages int) {
+	start = *pageNumber * *pageSize
+	if start > n {
+		start = n
+	}
+	end = start + *pageSize
+	if end > n {
+		end = n
+	}
+	totalPages = (n + *pageSize - 1) / *pageSize
+
+	return start, end, totalPages
+}
+
+func fakeSortedIds(ids []string) []string {
+	sort.Strings(ids)
+
+	return ids
+}
+
+func (f *fakeXilutionClient) GetUsers(organizationId *string, pageSize, pageNumber *int) (*xc.FetchUsersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.users {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.User{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.users[id])
+	}
+
+	return &xc.FetchUsersResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) CreateGitAccount(organizationId *string, gitAccount *xc.GitAccount) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *gitAccount
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.gitAccounts[created.ID] = &created
+
+	return fakeLocation(organizationId, "git-accounts", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetGitAccount(organizationId *string, gitAccountId *string) (*xc.GitAccount, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	gitAccount, ok := f.gitAccounts[*gitAccountId]
+	if !ok {
+		return nil, fakeNotFound("git account", gitAccountId)
+	}
+
+	found := *gitAccount
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetGitAccounts(organizationId *string, pageSize, pageNumber *int) (*xc.FetchGitAccountsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.gitAccounts {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.GitAccount{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.gitAccounts[id])
+	}
+
+	return &xc.FetchGitAccountsResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateGitAccount(organizationId *string, gitAccount *xc.GitAccount) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.gitAccounts[gitAccount.ID]
+	if !ok {
+		return fakeNotFound("git account", &gitAccount.ID)
+	}
+
+	updated := *gitAccount
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.gitAccounts[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteGitAccount(organizationId *string, gitAccountId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.gitAccounts[*gitAccountId]; !ok {
+		return fakeNotFound("git account", gitAccountId)
+	}
+	delete(f.gitAccounts, *gitAccountId)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateGitRepo(organizationId *string, gitRepo *xc.GitRepo) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *gitRepo
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.gitRepos[created.ID] = &created
+
+	return fakeLocation(organizationId, "git-repos", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetGitRepo(organizationId, gitRepoId *string) (*xc.GitRepo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	gitRepo, ok := f.gitRepos[*gitRepoId]
+	if !ok {
+		return nil, fakeNotFound("git repo", gitRepoId)
+	}
+
+	found := *gitRepo
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetGitRepos(organizationId *string, pageSize, pageNumber *int) (*xc.FetchGitReposResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.gitRepos {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.GitRepo{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.gitRepos[id])
+	}
+
+	return &xc.FetchGitReposResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateGitRepo(organizationId *string, gitRepo *xc.GitRepo) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.gitRepos[gitRepo.ID]
+	if !ok {
+		return fakeNotFound("git repo", &gitRepo.ID)
+	}
+
+	updated := *gitRepo
+	updated.Status = existing.Status
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.gitRepos[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteGitRepo(organizationId, gitRepoId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.gitRepos[*gitRepoId]; !ok {
+		return fakeNotFound("git repo", gitRepoId)
+	}
+	delete(f.gitRepos, *gitRepoId)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateGitRepoEvent(organizationId *string, gitRepoEvent *xc.GitRepoEvent) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	gitRepo, ok := f.gitRepos[gitRepoEvent.GitRepoId]
+	if !ok {
+		return nil, fakeNotFound("git repo", &gitRepoEvent.GitRepoId)
+	}
+	gitRepo.Status = ACTIVE
+
+	created := *gitRepoEvent
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.gitRepoEvents[created.ID] = &created
+
+	return fakeLocation(organizationId, "git-repo-events", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetGitRepoEvent(organizationId, eventId *string) (*xc.GitRepoEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	gitRepoEvent, ok := f.gitRepoEvents[*eventId]
+	if !ok {
+		return nil, fakeNotFound("git repo event", eventId)
+	}
+
+	found := *gitRepoEvent
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetGitRepoEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchGitRepoEventsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.gitRepoEvents {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.GitRepoEvent{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.gitRepoEvents[id])
+	}
+
+	return &xc.FetchGitRepoEventsResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) CreateCloudProvider(organizationId *string, cloudProvider *xc.CloudProvider) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *cloudProvider
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.cloudProviders[created.ID] = &created
+
+	return fakeLocation(organizationId, "cloud-providers", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetCloudProvider(organizationId *string, cloudProviderId *string) (*xc.CloudProvider, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cloudProvider, ok := f.cloudProviders[*cloudProviderId]
+	if !ok {
+		return nil, fakeNotFound("cloud provider", cloudProviderId)
+	}
+
+	found := *cloudProvider
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetCloudProviders(organizationId *string, pageSize, pageNumber *int) (*xc.FetchCloudProvidersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.cloudProviders {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.CloudProvider{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.cloudProviders[id])
+	}
+
+	return &xc.FetchCloudProvidersResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateCloudProvider(organizationId *string, cloudProvider *xc.CloudProvider) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.cloudProviders[cloudProvider.ID]
+	if !ok {
+		return fakeNotFound("cloud provider", &cloudProvider.ID)
+	}
+
+	updated := *cloudProvider
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.cloudProviders[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteCloudProvider(organizationId *string, cloudProviderId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.cloudProviders[*cloudProviderId]; !ok {
+		return fakeNotFound("cloud provider", cloudProviderId)
+	}
+	delete(f.cloudProviders, *cloudProviderId)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateVpcPipeline(organizationId *string, pipeline *xc.VpcPipeline) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *pipeline
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.vpcPipelines[created.ID] = &created
+
+	return fakeLocation(organizationId, "pipelines", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetVpcPipeline(organizationId *string, pipeline *string) (*xc.VpcPipeline, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	vpcPipeline, ok := f.vpcPipelines[*pipeline]
+	if !ok {
+		return nil, fakeNotFound("vpc pipeline", pipeline)
+	}
+
+	found := *vpcPipeline
+	found.Status = f.pipelineStatus(found.ID)
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetVpcPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchVpcPipelinesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.vpcPipelines {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.VpcPipeline{}
+	for _, id := range ids[start:end] {
+		found := *f.vpcPipelines[id]
+		found.Status = f.pipelineStatus(id)
+		content = append(content, found)
+	}
+
+	return &xc.FetchVpcPipelinesResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateVpcPipeline(organizationId *string, vpcPipeline *xc.VpcPipeline) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.vpcPipelines[vpcPipeline.ID]
+	if !ok {
+		return fakeNotFound("vpc pipeline", &vpcPipeline.ID)
+	}
+
+	updated := *vpcPipeline
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.vpcPipelines[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteVpcPipeline(organizationId *string, pipeline *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.vpcPipelines[*pipeline]; !ok {
+		return fakeNotFound("vpc pipeline", pipeline)
+	}
+	delete(f.vpcPipelines, *pipeline)
+	delete(f.pipelineStatuses, *pipeline)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateVpcPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.vpcPipelines[pipelineEvent.PipelineId]
+
+	return f.createPipelineEvent(organizationId, pipelineEvent, ok)
+}
+
+func (f *fakeXilutionClient) GetVpcPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvent(pipelineEventId)
+}
+
+func (f *fakeXilutionClient) GetVpcPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvents(pageSize, pageNumber, func(pipelineId string) bool {
+		_, ok := f.vpcPipelines[pipelineId]
+		return ok
+	})
+}
+
+func (f *fakeXilutionClient) CreateK8sPipeline(organizationId *string, k8sPipeline *xc.K8sPipeline) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *k8sPipeline
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.k8sPipelines[created.ID] = &created
+
+	return fakeLocation(organizationId, "pipelines", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetK8sPipeline(organizationId *string, k8sPipelineId *string) (*xc.K8sPipeline, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	k8sPipeline, ok := f.k8sPipelines[*k8sPipelineId]
+	if !ok {
+		return nil, fakeNotFound("k8s pipeline", k8sPipelineId)
+	}
+
+	found := *k8sPipeline
+	found.Status = f.pipelineStatus(found.ID)
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetK8sPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchK8sPipelinesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.k8sPipelines {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.K8sPipeline{}
+	for _, id := range ids[start:end] {
+		found := *f.k8sPipelines[id]
+		found.Status = f.pipelineStatus(id)
+		content = append(content, found)
+	}
+
+	return &xc.FetchK8sPipelinesResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateK8sPipeline(organizationId *string, k8sPipeline *xc.K8sPipeline) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.k8sPipelines[k8sPipeline.ID]
+	if !ok {
+		return fakeNotFound("k8s pipeline", &k8sPipeline.ID)
+	}
+
+	updated := *k8sPipeline
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.k8sPipelines[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteK8sPipeline(organizationId *string, k8sPipelineId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.k8sPipelines[*k8sPipelineId]; !ok {
+		return fakeNotFound("k8s pipeline", k8sPipelineId)
+	}
+	delete(f.k8sPipelines, *k8sPipelineId)
+	delete(f.pipelineStatuses, *k8sPipelineId)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateK8sPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.k8sPipelines[pipelineEvent.PipelineId]
+
+	return f.createPipelineEvent(organizationId, pipelineEvent, ok)
+}
+
+func (f *fakeXilutionClient) GetK8sPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvent(pipelineEventId)
+}
+
+func (f *fakeXilutionClient) GetK8sPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvents(pageSize, pageNumber, func(pipelineId string) bool {
+		_, ok := f.k8sPipelines[pipelineId]
+		return ok
+	})
+}
+
+func (f *fakeXilutionClient) CreateWordPressPipeline(organizationId *string, wordPress *xc.WordPressPipeline) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *wordPress
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.wordPressPipelines[created.ID] = &created
+
+	return fakeLocation(organizationId, "pipelines", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetWordPressPipeline(organizationId *string, wordPressId *string) (*xc.WordPressPipeline, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	wordPressPipeline, ok := f.wordPressPipelines[*wordPressId]
+	if !ok {
+		return nil, fakeNotFound("word press pipeline", wordPressId)
+	}
+
+	found := *wordPressPipeline
+	found.Status = f.pipelineStatus(found.ID)
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetWordPressPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchWordPressPipelinesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.wordPressPipelines {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.WordPressPipeline{}
+	for _, id := range ids[start:end] {
+		found := *f.wordPressPipelines[id]
+		found.Status = f.pipelineStatus(id)
+		content = append(content, found)
+	}
+
+	return &xc.FetchWordPressPipelinesResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateWordPressPipeline(organizationId *string, wordPress *xc.WordPressPipeline) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.wordPressPipelines[wordPress.ID]
+	if !ok {
+		return fakeNotFound("word press pipeline", &wordPress.ID)
+	}
+
+	updated := *wordPress
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.wordPressPipelines[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteWordPressPipeline(organizationId *string, wordPressId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.wordPressPipelines[*wordPressId]; !ok {
+		return fakeNotFound("word press pipeline", wordPressId)
+	}
+	delete(f.wordPressPipelines, *wordPressId)
+	delete(f.pipelineStatuses, *wordPressId)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateWordPressPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.wordPressPipelines[pipelineEvent.PipelineId]
+
+	return f.createPipelineEvent(organizationId, pipelineEvent, ok)
+}
+
+func (f *fakeXilutionClient) GetWordPressPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvent(pipelineEventId)
+}
+
+func (f *fakeXilutionClient) GetWordPressPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvents(pageSize, pageNumber, func(pipelineId string) bool {
+		_, ok := f.wordPressPipelines[pipelineId]
+		return ok
+	})
+}
+
+func (f *fakeXilutionClient) CreateStaticContentPipeline(organizationId *string, staticContent *xc.StaticContentPipeline) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *staticContent
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.staticContentPipelines[created.ID] = &created
+
+	return fakeLocation(organizationId, "pipelines", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetStaticContentPipeline(organizationId *string, staticContentId *string) (*xc.StaticContentPipeline, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	staticContentPipeline, ok := f.staticContentPipelines[*staticContentId]
+	if !ok {
+		return nil, fakeNotFound("static content pipeline", staticContentId)
+	}
+
+	found := *staticContentPipeline
+	found.Status = f.pipelineStatus(found.ID)
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetStaticContentPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchStaticContentPipelinesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.staticContentPipelines {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.StaticContentPipeline{}
+	for _, id := range ids[start:end] {
+		found := *f.staticContentPipelines[id]
+		found.Status = f.pipelineStatus(id)
+		content = append(content, found)
+	}
+
+	return &xc.FetchStaticContentPipelinesResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateStaticContentPipeline(organizationId *string, staticContent *xc.StaticContentPipeline) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.staticContentPipelines[staticContent.ID]
+	if !ok {
+		return fakeNotFound("static content pipeline", &staticContent.ID)
+	}
+
+	updated := *staticContent
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.staticContentPipelines[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteStaticContentPipeline(organizationId *string, staticContentId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.staticContentPipelines[*staticContentId]; !ok {
+		return fakeNotFound("static content pipeline", staticContentId)
+	}
+	delete(f.staticContentPipelines, *staticContentId)
+	delete(f.pipelineStatuses, *staticContentId)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateStaticContentPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.staticContentPipelines[pipelineEvent.PipelineId]
+
+	return f.createPipelineEvent(organizationId, pipelineEvent, ok)
+}
+
+func (f *fakeXilutionClient) GetStaticContentPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvent(pipelineEventId)
+}
+
+func (f *fakeXilutionClient) GetStaticContentPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvents(pageSize, pageNumber, func(pipelineId string) bool {
+		_, ok := f.staticContentPipelines[pipelineId]
+		return ok
+	})
+}
+
+func (f *fakeXilutionClient) CreateApiPipeline(organizationId *string, api *xc.ApiPipeline) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *api
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.apiPipelines[created.ID] = &created
+
+	return fakeLocation(organizationId, "pipelines", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetApiPipeline(organizationId *string, apiId *string) (*xc.ApiPipeline, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	apiPipeline, ok := f.apiPipelines[*apiId]
+	if !ok {
+		return nil, fakeNotFound("api pipeline", apiId)
+	}
+
+	found := *apiPipeline
+	found.Status = f.pipelineStatus(found.ID)
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetApiPipelines(organizationId *string, pageSize, pageNumber *int) (*xc.FetchApiPipelinesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.apiPipelines {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.ApiPipeline{}
+	for _, id := range ids[start:end] {
+		found := *f.apiPipelines[id]
+		found.Status = f.pipelineStatus(id)
+		content = append(content, found)
+	}
+
+	return &xc.FetchApiPipelinesResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdateApiPipeline(organizationId *string, api *xc.ApiPipeline) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.apiPipelines[api.ID]
+	if !ok {
+		return fakeNotFound("api pipeline", &api.ID)
+	}
+
+	updated := *api
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.apiPipelines[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeleteApiPipeline(organizationId *string, apiId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.apiPipelines[*apiId]; !ok {
+		return fakeNotFound("api pipeline", apiId)
+	}
+	delete(f.apiPipelines, *apiId)
+	delete(f.pipelineStatuses, *apiId)
+
+	return nil
+}
+
+func (f *fakeXilutionClient) CreateApiPipelineEvent(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.apiPipelines[pipelineEvent.PipelineId]
+
+	return f.createPipelineEvent(organizationId, pipelineEvent, ok)
+}
+
+func (f *fakeXilutionClient) GetApiPipelineEvent(organizationId *string, pipelineEventId *string) (*xc.PipelineEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvent(pipelineEventId)
+}
+
+func (f *fakeXilutionClient) GetApiPipelineEvents(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.getPipelineEvents(pageSize, pageNumber, func(pipelineId string) bool {
+		_, ok := f.apiPipelines[pipelineId]
+		return ok
+	})
+}
+
+func (f *fakeXilutionClient) CreatePipelinePrototype(organizationId *string, pipelinePrototype *xc.PipelinePrototype) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	created := *pipelinePrototype
+	created.ID = f.newId()
+	created.CreatedAt = fakeTimestamp
+	created.ModifiedAt = fakeTimestamp
+	f.pipelinePrototypes[created.ID] = &created
+
+	return fakeLocation(organizationId, "pipeline-prototypes", created.ID), nil
+}
+
+func (f *fakeXilutionClient) GetPipelinePrototype(organizationId, pipelinePrototypeId *string) (*xc.PipelinePrototype, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	pipelinePrototype, ok := f.pipelinePrototypes[*pipelinePrototypeId]
+	if !ok {
+		return nil, fakeNotFound("pipeline prototype", pipelinePrototypeId)
+	}
+
+	found := *pipelinePrototype
+
+	return &found, nil
+}
+
+func (f *fakeXilutionClient) GetPipelinePrototypes(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelinePrototypesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ids := []string{}
+	for id := range f.pipelinePrototypes {
+		ids = append(ids, id)
+	}
+	ids = fakeSortedIds(ids)
+
+	start, end, totalPages := fakePage(len(ids), pageSize, pageNumber)
+
+	content := []xc.PipelinePrototype{}
+	for _, id := range ids[start:end] {
+		content = append(content, *f.pipelinePrototypes[id])
+	}
+
+	return &xc.FetchPipelinePrototypesResponse{
+		Content:          content,
+		PageSize:         *pageSize,
+		PageNumber:       *pageNumber,
+		TotalPages:       totalPages,
+		NumberOfElements: len(content),
+		TotalElements:    len(ids),
+		FirstPage:        *pageNumber == 0,
+		LastPage:         *pageNumber >= totalPages-1,
+	}, nil
+}
+
+func (f *fakeXilutionClient) UpdatePipelinePrototype(organizationId *string, pipelinePrototype *xc.PipelinePrototype) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.pipelinePrototypes[pipelinePrototype.ID]
+	if !ok {
+		return fakeNotFound("pipeline prototype", &pipelinePrototype.ID)
+	}
+
+	updated := *pipelinePrototype
+	updated.CreatedAt = existing.CreatedAt
+	updated.ModifiedAt = fakeTimestamp
+	f.pipelinePrototypes[updated.ID] = &updated
+
+	return nil
+}
+
+func (f *fakeXilutionClient) DeletePipelinePrototype(organizationId *string, pipelinePrototypeId *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.pipelinePrototypes[*pipelinePrototypeId]; !ok {
+		return fakeNotFound("pipeline prototype", pipelinePrototypeId)
+	}
+	delete(f.pipelinePrototypes, *pipelinePrototypeId)
+
+	return nil
+}
@@ -1,11 +1,21 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
@@ -15,6 +25,74 @@ const UPDATE_ROLLBACK_COMPLETE = "UPDATE_ROLLBACK_COMPLETE"
 const SUCCEEDED = "SUCCEEDED"
 const FAILED = "FAILED"
 const NOT_FOUND = "NOT_FOUND"
+const ACTIVE = "ACTIVE"
+
+// pipelineTypes are the pipeline sizes the provider accepts. AWS_SMALL is the
+// only size Xilution is known to offer.
+var pipelineTypes = []string{"AWS_SMALL"}
+var pipelineEventTypes = []string{"PROVISION", "REPROVISION", "DEPROVISION", "RUN_NOW"}
+var pipelineEventOnDestroyActions = []string{"DEPROVISION", "NONE"}
+var gitRepoEventTypes = []string{"CREATE_REPO_FROM_TEMPLATE_REPO"}
+var gitProviders = []string{"GIT_HUB"}
+var cloudProviders = []string{"AWS"}
+
+var validateAwsAccountId = validation.ToDiagFunc(validation.StringMatch(
+	regexp.MustCompile(`^\d{12}$`),
+	"must be a 12 digit AWS account id",
+))
+
+var validateAwsRegion = validation.ToDiagFunc(validation.StringMatch(
+	regexp.MustCompile(`^[a-z]{2}(-gov)?-[a-z]+-\d$`),
+	"must be an AWS region, e.g. us-east-1",
+))
+
+const retainedInfrastructureDetail = "retain_infrastructure_on_destroy is set, so destroying this pipeline removed its Xilution record without deprovisioning it. The infrastructure is orphaned and has to be cleaned up outside of Terraform."
+
+// validatePipelineEventOnDestroy only accepts on_destroy = "DEPROVISION" on
+// PROVISION events. Changing triggers replaces an event, destroying the old
+// one before the new one is sent, so a PROVISION event deprovisions the
+// pipeline and provisions it again. Any other event type would tear the
+// infrastructure down on every trigger change and then send its event to a
+// pipeline that is no longer provisioned.
+func validatePipelineEventOnDestroy(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("on_destroy") || !d.NewValueKnown("event_type") {
+		return nil
+	}
+
+	eventType := d.Get("event_type").(string)
+	if d.Get("on_destroy").(string) == "DEPROVISION" && eventType != "PROVISION" {
+		return fmt.Errorf("on_destroy = \"DEPROVISION\" is only allowed with event_type = \"PROVISION\", got %q", eventType)
+	}
+
+	return nil
+}
+
+var validateDuration = validation.ToDiagFunc(func(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := time.ParseDuration(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a duration, e.g. 30s, got %q", k, v)}
+	}
+
+	return nil, nil
+})
+
+// expandHomeDir expands a leading ~ in a path to the user's home directory.
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, path[1:]), nil
+}
 
 func getIdFromLocationUrl(location *string) *string {
 	index := strings.LastIndex(*location, "/")
@@ -23,147 +101,698 @@ func getIdFromLocationUrl(location *string) *string {
 	return &id
 }
 
-func waitForPipelineEventToComplete(
+// isNotFoundError reports whether the Xilution API answered 404.
+func isNotFoundError(err error) bool {
+	var notFound *notFoundError
+
+	return errors.As(err, &notFound)
+}
+
+// getOrganizationId returns the resource's organization_id, falling back to
+// the provider's organization_id.
+func getOrganizationId(d *schema.ResourceData, m interface{}) (string, error) {
+	if organizationId, ok := d.GetOk("organization_id"); ok {
+		return organizationId.(string), nil
+	}
+
+	if organizationId := m.(*providerMeta).organizationId; organizationId != "" {
+		return organizationId, nil
+	}
+
+	return "", fmt.Errorf("organization_id must be set on the resource or the provider")
+}
+
+// getOwningUserId returns the resource's owning_user_id, falling back to the
+// provider's owning_user_id and then to the authenticated user.
+func getOwningUserId(d *schema.ResourceData, m interface{}) (string, error) {
+	if owningUserId, ok := d.GetOk("owning_user_id"); ok {
+		return owningUserId.(string), nil
+	}
+
+	return m.(*providerMeta).defaultOwningUserId()
+}
+
+// importStateWithOrganizationId imports <organization_id>/<id>, or just <id>
+// into the provider's organization.
+func importStateWithOrganizationId(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	organizationId, id := m.(*providerMeta).organizationId, d.Id()
+	if parts := strings.SplitN(d.Id(), "/", 2); len(parts) == 2 {
+		organizationId, id = parts[0], parts[1]
+	}
+
+	if organizationId == "" || id == "" {
+		return nil, fmt.Errorf("unexpected format of import id (%s), expected <organization_id>/<id>", d.Id())
+	}
+
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// listPageSize is the page size the plural data sources request while paging
+// through a collection.
+const listPageSize = 100
+
+// isLastPage reports whether a page of a collection response is the last one.
+func isLastPage(pageNumber int, numberOfElements int, totalPages int, lastPage bool) bool {
+	return lastPage || numberOfElements == 0 || pageNumber+1 >= totalPages
+}
+
+// listFilter is the set of optional filters a plural data source was
+// configured with, or the name a singular data source looks an object up by.
+type listFilter struct {
+	name      string
+	nameRegex *regexp.Regexp
+	values    map[string]string
+}
+
+// newListFilter reads name_regex and the given exact match filter attributes
+// of a plural data source.
+func newListFilter(d *schema.ResourceData, attributes ...string) (*listFilter, error) {
+	filter := &listFilter{
+		values: map[string]string{},
+	}
+
+	if nameRegex, ok := d.GetOk("name_regex"); ok {
+		r, err := regexp.Compile(nameRegex.(string))
+		if err != nil {
+			return nil, err
+		}
+		filter.nameRegex = r
+	}
+
+	for _, attribute := range attributes {
+		if value, ok := d.GetOk(attribute); ok {
+			filter.values[attribute] = value.(string)
+		}
+	}
+
+	return filter, nil
+}
+
+// newLookupFilter reads the name a singular data source looks its object up
+// by, along with the given attributes that narrow the lookup.
+func newLookupFilter(d *schema.ResourceData, nameAttribute string, attributes ...string) *listFilter {
+	filter := &listFilter{
+		name:   d.Get(nameAttribute).(string),
+		values: map[string]string{},
+	}
+
+	for _, attribute := range attributes {
+		if value, ok := d.GetOk(attribute); ok {
+			filter.values[attribute] = value.(string)
+		}
+	}
+
+	return filter
+}
+
+// matches reports whether an object with the given name and attribute values
+// passes every configured filter.
+func (f *listFilter) matches(name string, values map[string]string) bool {
+	if f.name != "" && name != f.name {
+		return false
+	}
+
+	if f.nameRegex != nil && !f.nameRegex.MatchString(name) {
+		return false
+	}
+
+	for attribute, wanted := range f.values {
+		if values[attribute] != wanted {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupId returns the id of the one object a singular data source's name
+// lookup matched, or an error when it matched none or several.
+func lookupId(kind string, name string, ids []string) (string, error) {
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s named %q found", kind, name)
+	case 1:
+		return ids[0], nil
+	}
+
+	return "", fmt.Errorf("%d %ss named %q found (%s), set id to choose one", len(ids), kind, name, strings.Join(ids, ", "))
+}
+
+// normalizeJson is the StateFunc of JSON string attributes. It stores the
+// JSON compacted with sorted keys, the way it reads back from the API, so
+// key order and whitespace in the configuration don't show up as changes.
+func normalizeJson(v interface{}) string {
+	normalized, err := structure.NormalizeJsonString(v)
+	if err != nil {
+		// Invalid JSON is reported by validation.StringIsJSON.
+		return v.(string)
+	}
+
+	return normalized
+}
+
+// dataSourceListElem turns the schema of a singular data source into the
+// element schema of the matching plural data source, or the schema of a
+// resource's nested block into the one its data source reports, where every
+// attribute is computed.
+func dataSourceListElem(s map[string]*schema.Schema) *schema.Resource {
+	elem := map[string]*schema.Schema{}
+	for key, attribute := range s {
+		computed := &schema.Schema{
+			Type:      attribute.Type,
+			Computed:  true,
+			Sensitive: attribute.Sensitive,
+			Elem:      attribute.Elem,
+		}
+		if resource, ok := attribute.Elem.(*schema.Resource); ok {
+			computed.Elem = dataSourceListElem(resource.Schema)
+		}
+		elem[key] = computed
+	}
+
+	return &schema.Resource{
+		Schema: elem,
+	}
+}
+
+// flattenPipelineStatus returns a pipeline's infrastructure status and the
+// status of its latest up and down executions, any of which may be empty.
+func flattenPipelineStatus(status *xc.PipelineStatus) (string, string, string) {
+	infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus := "", "", ""
+	if status != nil {
+		infrastructureStatus = status.InfrastructureStatus
+		if status.ContinuousIntegrationStatus != nil {
+			latestUpExecutionStatus = status.ContinuousIntegrationStatus.LatestUpExecutionStatus
+			latestDownExecutionStatus = status.ContinuousIntegrationStatus.LatestDownExecutionStatus
+		}
+	}
+
+	return infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus
+}
+
+// setPipelineStatus writes a pipeline's status to its computed
+// infrastructure_status, latest_up_execution_status and
+// latest_down_execution_status attributes.
+func setPipelineStatus(d *schema.ResourceData, status *xc.PipelineStatus) error {
+	infrastructureStatus, latestUpExecutionStatus, latestDownExecutionStatus := flattenPipelineStatus(status)
+
+	if err := d.Set("infrastructure_status", infrastructureStatus); err != nil {
+		return err
+	}
+
+	if err := d.Set("latest_up_execution_status", latestUpExecutionStatus); err != nil {
+		return err
+	}
+
+	return d.Set("latest_down_execution_status", latestDownExecutionStatus)
+}
+
+// sendPipelineEvent creates a pipeline event and waits for the pipeline to
+// reach the state the event leads to.
+func sendPipelineEvent(
+	ctx context.Context,
 	eventType string,
+	organizationId string,
+	owningUserId string,
+	pipelineId string,
 	timeout time.Duration,
-	waitIncrement time.Duration,
+	createPipelineEventFunc func(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error),
 	getPipelineStatusFunc func() (*xc.PipelineStatus, error),
 ) error {
-	if eventType == "PROVISION" || eventType == "RUN_NOW" {
-		err := waitForPipelineUpToSucceeded(timeout, waitIncrement, getPipelineStatusFunc)
+	log.Printf("[DEBUG] Sending %s event to pipeline %s", eventType, pipelineId)
+
+	_, err := createPipelineEventFunc(&organizationId, &xc.PipelineEvent{
+		Type:           "pipeline-event",
+		PipelineId:     pipelineId,
+		OrganizationId: organizationId,
+		OwningUserId:   owningUserId,
+		EventType:      eventType,
+	})
+	if err != nil {
+		return err
+	}
+
+	return waitForPipelineEventToComplete(ctx, eventType, timeout, getPipelineStatusFunc)
+}
+
+// dependentPipeline is a pipeline that runs inside another one, e.g. a k8s
+// pipeline in a vpc pipeline, along with the pipelines that run inside it.
+type dependentPipeline struct {
+	resourceType            string
+	id                      string
+	name                    string
+	owningUserId            string
+	dependents              []dependentPipeline
+	createPipelineEventFunc func(organizationId *string, pipelineEvent *xc.PipelineEvent) (*string, error)
+	getPipelineStatusFunc   func() (*xc.PipelineStatus, error)
+}
+
+// dependentPipelinesDiagnostics refuses to destroy a pipeline that other
+// pipelines still depend on.
+func dependentPipelinesDiagnostics(kind string, id string, dependents []dependentPipeline) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s %s still has dependent pipelines", kind, id),
+			Detail: fmt.Sprintf(
+				"Destroying it would deprovision infrastructure these pipelines run in:\n\n%s\n\nDestroy them first, or set force_destroy to deprovision them along with it.",
+				strings.Join(describeDependentPipelines(dependents, ""), "\n"),
+			),
+		},
+	}
+}
+
+func describeDependentPipelines(dependents []dependentPipeline, indent string) []string {
+	lines := []string{}
+	for _, dependent := range dependents {
+		lines = append(lines, fmt.Sprintf("%s- %s %q (%s)", indent, dependent.resourceType, dependent.name, dependent.id))
+		lines = append(lines, describeDependentPipelines(dependent.dependents, indent+"  ")...)
+	}
+
+	return lines
+}
+
+// deprovisionDependentPipelines deals with the pipelines that depend on a
+// provisioned pipeline being destroyed. Without force_destroy the destroy is
+// refused. With force_destroy the dependents are deprovisioned first, within
+// the delete timeout. Only their infrastructure is removed: their records, and
+// their terraform state with provisioned = true, are kept, which the returned
+// warning tells the user.
+func deprovisionDependentPipelines(ctx context.Context, d *schema.ResourceData, kind string, organizationId string, dependents []dependentPipeline) diag.Diagnostics {
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	if !d.Get("force_destroy").(bool) {
+		return dependentPipelinesDiagnostics(kind, d.Id(), dependents)
+	}
+
+	err := deprovisionPipelines(ctx, organizationId, dependents, d.Timeout(schema.TimeoutDelete))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return deprovisionedDependentPipelinesDiagnostics(kind, d.Id(), dependents)
+}
+
+// deprovisionPipelines deprovisions each pipeline after the pipelines that
+// depend on it, so no infrastructure is torn down while still in use. The
+// pipelines share ctx's deadline, so each one only waits for what is left of
+// it.
+func deprovisionPipelines(ctx context.Context, organizationId string, dependents []dependentPipeline, timeout time.Duration) error {
+	for _, dependent := range dependents {
+		err := deprovisionPipelines(ctx, organizationId, dependent.dependents, timeout)
 		if err != nil {
 			return err
 		}
-	} else if eventType == "REPROVISION" {
-		err := waitForPipelineInfrastructureUpdateComplete(timeout, waitIncrement, getPipelineStatusFunc)
+
+		status, err := dependent.getPipelineStatusFunc()
 		if err != nil {
 			return err
 		}
-	} else if eventType == "DEPROVISION" {
-		err := waitForPipelineInfrastructureNotFound(timeout, waitIncrement, getPipelineStatusFunc)
+
+		if !isPipelineProvisioned(status) {
+			continue
+		}
+
+		remaining := remainingTimeout(ctx, timeout)
+		if remaining <= 0 {
+			return fmt.Errorf("no time is left to deprovision %s %s, increase the delete timeout", dependent.resourceType, dependent.id)
+		}
+
+		err = sendPipelineEvent(ctx, "DEPROVISION", organizationId, dependent.owningUserId, dependent.id, remaining, dependent.createPipelineEventFunc, dependent.getPipelineStatusFunc)
 		if err != nil {
-			return err
+			return fmt.Errorf("unable to deprovision %s %s: %w", dependent.resourceType, dependent.id, err)
 		}
 	}
 
 	return nil
 }
 
-func waitForPipelineUpToSucceeded(
-	timeout time.Duration,
-	waitIncrement time.Duration,
-	getPipelineStatusFunc func() (*xc.PipelineStatus, error),
-) error {
-	if waitIncrement < 5*time.Second {
-		return errors.New("wait increment must be greater than 5 seconds")
+// deprovisionedDependentPipelinesDiagnostics tells the user that force_destroy
+// only deprovisioned the dependent pipelines.
+func deprovisionedDependentPipelinesDiagnostics(kind string, id string, dependents []dependentPipeline) diag.Diagnostics {
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The dependent pipelines of %s %s were deprovisioned", kind, id),
+			Detail: fmt.Sprintf(
+				"force_destroy deprovisioned these pipelines, but kept their records:\n\n%s\n\nThey stay in state with provisioned = true until they are refreshed, after which applying them provisions them again.",
+				strings.Join(describeDependentPipelines(dependents, ""), "\n"),
+			),
+		},
+	}
+}
+
+// remainingTimeout returns what is left of ctx's deadline, capped at timeout.
+func remainingTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout
 	}
 
-	done := false
-	start := time.Now()
-	for !done {
-		status, err := getPipelineStatusFunc()
+	if remaining := time.Until(deadline); remaining < timeout {
+		return remaining
+	}
+
+	return timeout
+}
+
+// listPipelineEvents pages through an organization's events of one kind of
+// pipeline and returns the ones that pass the filter.
+func listPipelineEvents(
+	organizationId string,
+	filter *listFilter,
+	getPipelineEventsFunc func(organizationId *string, pageSize, pageNumber *int) (*xc.FetchPipelineEventsResponse, error),
+) ([]xc.PipelineEvent, error) {
+	pipelineEvents := []xc.PipelineEvent{}
+
+	pageSize := listPageSize
+	for pageNumber := 0; ; pageNumber++ {
+		response, err := getPipelineEventsFunc(&organizationId, &pageSize, &pageNumber)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if status != nil {
-			infrastructureStatus := status.InfrastructureStatus
-			if infrastructureStatus == CREATE_COMPLETE {
-				continuousIntegrationStatus := status.ContinuousIntegrationStatus
-				if continuousIntegrationStatus != nil {
-					latestUpExecutionStatus := continuousIntegrationStatus.LatestUpExecutionStatus
-					if latestUpExecutionStatus == SUCCEEDED {
-						done = true
-						continue
-					} else if strings.HasSuffix(latestUpExecutionStatus, FAILED) {
-						return fmt.Errorf("pipeline up status is %s", latestUpExecutionStatus)
-					}
-				}
-			} else if strings.HasSuffix(infrastructureStatus, FAILED) {
-				return fmt.Errorf("pipeline infrastructure status is %s", infrastructureStatus)
+
+		for _, pipelineEvent := range response.Content {
+			if filter.matches("", map[string]string{
+				"pipeline_id":    pipelineEvent.PipelineId,
+				"event_type":     pipelineEvent.EventType,
+				"owning_user_id": pipelineEvent.OwningUserId,
+			}) {
+				pipelineEvents = append(pipelineEvents, pipelineEvent)
 			}
 		}
 
-		if time.Since(start) > timeout {
-			return errors.New("timeout waiting for pipeline up to succeed")
+		if isLastPage(pageNumber, len(response.Content), response.TotalPages, response.LastPage) {
+			break
 		}
-		time.Sleep(waitIncrement)
 	}
 
-	return nil
+	return pipelineEvents, nil
 }
 
-func waitForPipelineInfrastructureUpdateComplete(
+func flattenPipelineEvent(pipelineEvent xc.PipelineEvent) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              pipelineEvent.ID,
+		"organization_id": pipelineEvent.OrganizationId,
+		"pipeline_id":     pipelineEvent.PipelineId,
+		"event_type":      pipelineEvent.EventType,
+		"owning_user_id":  pipelineEvent.OwningUserId,
+		"created_at":      pipelineEvent.CreatedAt,
+		"modified_at":     pipelineEvent.ModifiedAt,
+	}
+}
+
+// isPipelineProvisioned reports whether a pipeline has infrastructure.
+func isPipelineProvisioned(status *xc.PipelineStatus) bool {
+	return status != nil && status.InfrastructureStatus != NOT_FOUND
+}
+
+// pipelineProvisioningEventType returns the event that brings a pipeline's
+// infrastructure in line with its provisioned attribute: PROVISION or
+// DEPROVISION when provisioned changes, REPROVISION when any of the given
+// attributes change on a provisioned pipeline, or "" when no event is needed.
+func pipelineProvisioningEventType(d *schema.ResourceData, status *xc.PipelineStatus, reprovisionAttributes ...string) string {
+	provisioned := isPipelineProvisioned(status)
+
+	if d.HasChange("provisioned") {
+		wanted := d.Get("provisioned").(bool)
+		if wanted && !provisioned {
+			return "PROVISION"
+		}
+		if !wanted && provisioned {
+			return "DEPROVISION"
+		}
+		return ""
+	}
+
+	if provisioned && d.Get("provisioned").(bool) && d.HasChanges(reprovisionAttributes...) {
+		return "REPROVISION"
+	}
+
+	return ""
+}
+
+// setPipelineProvisioned records whether a pipeline that is managed with
+// provisioned = true still has infrastructure, so infrastructure removed
+// outside of Terraform shows up as a change.
+func setPipelineProvisioned(d *schema.ResourceData, status *xc.PipelineStatus) error {
+	if !d.Get("provisioned").(bool) {
+		return nil
+	}
+
+	return d.Set("provisioned", isPipelineProvisioned(status))
+}
+
+func waitForPipelineEventToComplete(
+	ctx context.Context,
+	eventType string,
 	timeout time.Duration,
-	waitIncrement time.Duration,
 	getPipelineStatusFunc func() (*xc.PipelineStatus, error),
 ) error {
-	if waitIncrement < 5*time.Second {
-		return errors.New("wait increment must be greater than 5 seconds")
+	switch eventType {
+	case "PROVISION", "RUN_NOW":
+		return waitForPipelineUpToSucceeded(ctx, timeout, getPipelineStatusFunc)
+	case "REPROVISION":
+		return waitForPipelineInfrastructureUpdateComplete(ctx, timeout, getPipelineStatusFunc)
+	case "DEPROVISION":
+		return waitForPipelineInfrastructureNotFound(ctx, timeout, getPipelineStatusFunc)
 	}
 
-	done := false
-	start := time.Now()
-	for !done {
-		status, err := getPipelineStatusFunc()
-		if err != nil {
-			return err
-		}
-		if status != nil {
+	return fmt.Errorf("unable to wait for unsupported pipeline event type %s", eventType)
+}
+
+func waitForPipelineUpToSucceeded(
+	ctx context.Context,
+	timeout time.Duration,
+	getPipelineStatusFunc func() (*xc.PipelineStatus, error),
+) error {
+	waiter := &stateWaiter{
+		Description: "pipeline up to succeed",
+		Pending:     []string{"CREATE_IN_PROGRESS", "IN_PROGRESS"},
+		Target:      []string{SUCCEEDED},
+		Timeout:     timeout,
+		Refresh: func() (string, error) {
+			status, err := getPipelineStatusFunc()
+			if err != nil {
+				return "", err
+			}
+			if status == nil {
+				return "", nil
+			}
+
+			infrastructureStatus := status.InfrastructureStatus
+			if strings.HasSuffix(infrastructureStatus, FAILED) {
+				return "", fmt.Errorf("pipeline infrastructure status is %s", infrastructureStatus)
+			}
+			if infrastructureStatus != CREATE_COMPLETE || status.ContinuousIntegrationStatus == nil {
+				return infrastructureStatus, nil
+			}
+
+			latestUpExecutionStatus := status.ContinuousIntegrationStatus.LatestUpExecutionStatus
+			if strings.HasSuffix(latestUpExecutionStatus, FAILED) {
+				return "", fmt.Errorf("pipeline up status is %s", latestUpExecutionStatus)
+			}
+
+			return latestUpExecutionStatus, nil
+		},
+	}
+
+	return waiter.wait(ctx)
+}
+
+func waitForPipelineInfrastructureUpdateComplete(
+	ctx context.Context,
+	timeout time.Duration,
+	getPipelineStatusFunc func() (*xc.PipelineStatus, error),
+) error {
+	waiter := &stateWaiter{
+		Description: "pipeline infrastructure update to complete",
+		Pending:     []string{"UPDATE_IN_PROGRESS", "UPDATE_COMPLETE_CLEANUP_IN_PROGRESS"},
+		Target:      []string{UPDATE_COMPLETE},
+		Timeout:     timeout,
+		Refresh: func() (string, error) {
+			status, err := getPipelineStatusFunc()
+			if err != nil {
+				return "", err
+			}
+			if status == nil {
+				return "", nil
+			}
+
 			infrastructureStatus := status.InfrastructureStatus
-			if infrastructureStatus == UPDATE_COMPLETE {
-				done = true
-				continue
-			} else if infrastructureStatus == UPDATE_ROLLBACK_COMPLETE ||
+			if infrastructureStatus == UPDATE_ROLLBACK_COMPLETE ||
 				strings.HasSuffix(infrastructureStatus, FAILED) {
-				return fmt.Errorf("pipeline infrastructure status is %s", infrastructureStatus)
+				return "", fmt.Errorf("pipeline infrastructure status is %s", infrastructureStatus)
 			}
-		}
 
-		if time.Since(start) > timeout {
-			return errors.New("timeout waiting for pipeline infrastructure update to complete")
-		}
-		time.Sleep(waitIncrement)
+			return infrastructureStatus, nil
+		},
 	}
 
-	return nil
+	return waiter.wait(ctx)
 }
 
 func waitForPipelineInfrastructureNotFound(
+	ctx context.Context,
 	timeout time.Duration,
-	waitIncrement time.Duration,
 	getPipelineStatusFunc func() (*xc.PipelineStatus, error),
 ) error {
-	if waitIncrement < 5*time.Second {
-		return errors.New("wait increment must be greater than 5 seconds")
+	waiter := &stateWaiter{
+		Description: "pipeline infrastructure to be not found",
+		Pending:     []string{"DELETE_IN_PROGRESS"},
+		Target:      []string{NOT_FOUND},
+		Timeout:     timeout,
+		// The infrastructure status can briefly report NOT_FOUND while a
+		// deprovision is still being picked up, so it has to hold steady.
+		ContinuousTargetOccurence: 6,
+		Refresh: func() (string, error) {
+			status, err := getPipelineStatusFunc()
+			if err != nil {
+				return "", err
+			}
+			if status == nil {
+				return "", nil
+			}
+
+			infrastructureStatus := status.InfrastructureStatus
+			if strings.HasSuffix(infrastructureStatus, FAILED) {
+				return "", fmt.Errorf("pipeline infrastructure status is %s", infrastructureStatus)
+			}
+
+			return infrastructureStatus, nil
+		},
+	}
+
+	return waiter.wait(ctx)
+}
+
+func waitForGitRepoToBeActive(
+	ctx context.Context,
+	timeout time.Duration,
+	getGitRepoStatusFunc func() (string, error),
+) error {
+	// The git repo has no status until it is ready.
+	waiter := &stateWaiter{
+		Description: "git repo to be active",
+		Pending:     []string{""},
+		Target:      []string{ACTIVE},
+		Timeout:     timeout,
+		Refresh:     getGitRepoStatusFunc,
+	}
+
+	return waiter.wait(ctx)
+}
+
+// Poll timings shared by every stateWaiter. They are variables so tests can
+// shorten them.
+var (
+	waitDelay       = 5 * time.Second
+	waitMinInterval = 5 * time.Second
+	waitMaxInterval = 60 * time.Second
+)
+
+// stateWaiter polls Refresh until it reports one of the Target states. The
+// interval between polls starts at waitMinInterval and backs off
+// exponentially, with jitter, up to waitMaxInterval. Refresh returns an error
+// to stop waiting on a terminal failure. Pending lists the states expected on
+// the way to Target. It is only logged, since the API may report others.
+type stateWaiter struct {
+	Description               string
+	Pending                   []string
+	Target                    []string
+	Refresh                   func() (string, error)
+	Timeout                   time.Duration
+	ContinuousTargetOccurence int
+}
+
+func (w *stateWaiter) wait(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	state := ""
+	interval := waitMinInterval
+	targetOccurence := 0
+
+	if err := sleepWithContext(ctx, waitDelay); err != nil {
+		return w.stopped(err, state)
 	}
 
-	done := false
-	start := time.Now()
-	notFoundCount := 0
-	for !done {
-		status, err := getPipelineStatusFunc()
+	for attempt := 1; ; attempt++ {
+		var err error
+		state, err = w.Refresh()
 		if err != nil {
 			return err
 		}
-		if status != nil {
-			infrastructureStatus := status.InfrastructureStatus
-			if infrastructureStatus == NOT_FOUND {
-				if notFoundCount > 5 {
-					done = true
-					continue
-				}
-
-				notFoundCount = notFoundCount + 1
-			} else if strings.HasSuffix(infrastructureStatus, FAILED) {
-				return fmt.Errorf("pipeline infrastructure status is %s", infrastructureStatus)
+
+		log.Printf("[DEBUG] Waiting for %s (attempt %d): state is %q, pending %q, target %q", w.Description, attempt, state, w.Pending, w.Target)
+
+		if containsString(w.Target, state) {
+			targetOccurence = targetOccurence + 1
+			if targetOccurence >= w.ContinuousTargetOccurence {
+				return nil
 			}
+
+			interval = waitMinInterval
+		} else {
+			targetOccurence = 0
+		}
+
+		if err := sleepWithContext(ctx, jitter(interval)); err != nil {
+			return w.stopped(err, state)
 		}
 
-		if time.Since(start) > timeout {
-			return errors.New("timeout waiting for pipeline infrastructure to be not found")
+		interval = interval * 2
+		if interval > waitMaxInterval {
+			interval = waitMaxInterval
 		}
-		time.Sleep(waitIncrement)
 	}
+}
 
-	return nil
+func (w *stateWaiter) stopped(err error, state string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timeout waiting for %s after %s, last state was %q", w.Description, w.Timeout, state)
+	}
+
+	return fmt.Errorf("stopped waiting for %s: %w", w.Description, err)
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// jitter returns a random duration between half of d and d.
+func jitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+
+	half := d / 2
+
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	cases := map[error]bool{
+		&notFoundError{}:                         true,
+		fmt.Errorf("read: %w", &notFoundError{}): true,
+		errors.New("git repo abc not found"):     false,
+		errors.New("Internal Server Error"):      false,
+		nil:                                      false,
+	}
+
+	for err, expected := range cases {
+		if actual := isNotFoundError(err); actual != expected {
+			t.Errorf("isNotFoundError(%v) = %t, expected %t", err, actual, expected)
+		}
+	}
+}
+
+func TestImportStateWithOrganizationId(t *testing.T) {
+	d := resourceVpcPipeline().Data(nil)
+	d.SetId("org-1/pipeline-1")
+
+	imported, err := importStateWithOrganizationId(context.Background(), d, &providerMeta{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if imported[0].Id() != "pipeline-1" {
+		t.Errorf("id = %s, expected pipeline-1", imported[0].Id())
+	}
+	if organizationId := imported[0].Get("organization_id").(string); organizationId != "org-1" {
+		t.Errorf("organization_id = %s, expected org-1", organizationId)
+	}
+
+	d.SetId("pipeline-2")
+	imported, err = importStateWithOrganizationId(context.Background(), d, &providerMeta{organizationId: "org-2"})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if organizationId := imported[0].Get("organization_id").(string); imported[0].Id() != "pipeline-2" || organizationId != "org-2" {
+		t.Errorf("expected a bare id to import into the provider organization, got %s/%s", organizationId, imported[0].Id())
+	}
+
+	for _, id := range []string{"pipeline-1", "org-1/", "/pipeline-1"} {
+		d.SetId(id)
+		if _, err := importStateWithOrganizationId(context.Background(), d, &providerMeta{}); err == nil {
+			t.Errorf("expected an error importing %q", id)
+		}
+	}
+}
+
+func TestGetOrganizationId(t *testing.T) {
+	d := resourceVpcPipeline().Data(nil)
+
+	if _, err := getOrganizationId(d, &providerMeta{}); err == nil {
+		t.Fatal("expected an error without a resource or provider organization_id")
+	}
+
+	if organizationId, _ := getOrganizationId(d, &providerMeta{organizationId: "org-2"}); organizationId != "org-2" {
+		t.Errorf("organization_id = %s, expected the provider's org-2", organizationId)
+	}
+
+	d.Set("organization_id", "org-1")
+	if organizationId, _ := getOrganizationId(d, &providerMeta{organizationId: "org-2"}); organizationId != "org-1" {
+		t.Errorf("organization_id = %s, expected the resource's org-1", organizationId)
+	}
+}
+
+func testPipelineStatusSequence(statuses ...xc.PipelineStatus) func() (*xc.PipelineStatus, error) {
+	return func() (*xc.PipelineStatus, error) {
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		return &status, nil
+	}
+}
+
+func TestValidatePipelineEventOnDestroy(t *testing.T) {
+	cases := []struct {
+		eventType string
+		onDestroy string
+		valid     bool
+	}{
+		{"PROVISION", "DEPROVISION", true},
+		{"PROVISION", "NONE", true},
+		{"RUN_NOW", "NONE", true},
+		{"RUN_NOW", "DEPROVISION", false},
+		{"REPROVISION", "DEPROVISION", false},
+	}
+
+	resources := map[string]*schema.Resource{
+		"xilution_vpc_pipeline_event":            resourceVpcPipelineEvent(),
+		"xilution_k8s_pipeline_event":            resourceK8sPipelineEvent(),
+		"xilution_word_press_pipeline_event":     resourceWordPressPipelineEvent(),
+		"xilution_static_content_pipeline_event": resourceStaticContentPipelineEvent(),
+		"xilution_api_pipeline_event":            resourceApiPipelineEvent(),
+	}
+
+	for name, r := range resources {
+		for _, c := range cases {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"organization_id": "org-1",
+				"owning_user_id":  "user-1",
+				"pipeline_id":     "pipeline-1",
+				"event_type":      c.eventType,
+				"on_destroy":      c.onDestroy,
+			})
+
+			_, err := r.Diff(context.Background(), nil, config, testProviderMeta(newFakeXilutionClient()))
+			if valid := err == nil; valid != c.valid {
+				t.Errorf("%s: event_type %s with on_destroy %s: expected valid %t, got %v", name, c.eventType, c.onDestroy, c.valid, err)
+			}
+		}
+	}
+}
+
+func TestWaitForPipelineEventToComplete_unsupportedEventType(t *testing.T) {
+	err := waitForPipelineEventToComplete(context.Background(), "PROVISON", time.Second, testPipelineStatusSequence(
+		fakeStatus(CREATE_COMPLETE, SUCCEEDED),
+	))
+	if err == nil || !strings.Contains(err.Error(), "unsupported pipeline event type PROVISON") {
+		t.Fatalf("expected an unsupported event type error, got %v", err)
+	}
+}
+
+func TestWaitForPipelineUpToSucceeded(t *testing.T) {
+	testShortenWaits(t)
+
+	err := waitForPipelineUpToSucceeded(context.Background(), time.Second, testPipelineStatusSequence(
+		fakeStatus("CREATE_IN_PROGRESS", ""),
+		fakeStatus(CREATE_COMPLETE, "IN_PROGRESS"),
+		fakeStatus(CREATE_COMPLETE, SUCCEEDED),
+	))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	err = waitForPipelineUpToSucceeded(context.Background(), time.Second, testPipelineStatusSequence(
+		fakeStatus("CREATE_IN_PROGRESS", ""),
+		fakeStatus(CREATE_COMPLETE, "IN_PROGRESS"),
+		fakeStatus(CREATE_COMPLETE, FAILED),
+	))
+	if err == nil || !strings.Contains(err.Error(), "pipeline up status is FAILED") {
+		t.Fatalf("expected the up failure, got %v", err)
+	}
+}
+
+func TestWaitForPipelineInfrastructureUpdateComplete(t *testing.T) {
+	testShortenWaits(t)
+
+	err := waitForPipelineInfrastructureUpdateComplete(context.Background(), time.Second, testPipelineStatusSequence(
+		fakeStatus("UPDATE_IN_PROGRESS", ""),
+		fakeStatus(UPDATE_ROLLBACK_COMPLETE, ""),
+	))
+	if err == nil || !strings.Contains(err.Error(), UPDATE_ROLLBACK_COMPLETE) {
+		t.Fatalf("expected the rollback to fail the wait, got %v", err)
+	}
+}
+
+func TestWaitForPipelineInfrastructureNotFound(t *testing.T) {
+	testShortenWaits(t)
+
+	calls := 0
+	getPipelineStatusFunc := testPipelineStatusSequence(
+		fakeStatus("DELETE_IN_PROGRESS", ""),
+		fakeStatus(NOT_FOUND, ""),
+	)
+
+	err := waitForPipelineInfrastructureNotFound(context.Background(), time.Second, func() (*xc.PipelineStatus, error) {
+		calls = calls + 1
+		return getPipelineStatusFunc()
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if calls != 7 {
+		t.Errorf("status was polled %d times, expected NOT_FOUND to be confirmed 6 times", calls)
+	}
+}
+
+func TestStateWaiter_unexpectedState(t *testing.T) {
+	testShortenWaits(t)
+
+	states := []string{"WORKING", "SOMETHING_ELSE", "DONE"}
+	waiter := &stateWaiter{
+		Description: "something",
+		Pending:     []string{"WORKING"},
+		Target:      []string{"DONE"},
+		Timeout:     time.Second,
+		Refresh: func() (string, error) {
+			state := states[0]
+			states = states[1:]
+			return state, nil
+		},
+	}
+
+	if err := waiter.wait(context.Background()); err != nil {
+		t.Fatalf("expected a state outside of pending to keep waiting, got %s", err)
+	}
+}
+
+func TestStateWaiter_timeout(t *testing.T) {
+	testShortenWaits(t)
+
+	waiter := &stateWaiter{
+		Description: "something",
+		Target:      []string{"DONE"},
+		Timeout:     20 * time.Millisecond,
+		Refresh: func() (string, error) {
+			return "WORKING", nil
+		},
+	}
+
+	err := waiter.wait(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `timeout waiting for something after 20ms, last state was "WORKING"`) {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+}
+
+func TestStateWaiter_cancelled(t *testing.T) {
+	testShortenWaits(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	waiter := &stateWaiter{
+		Description: "something",
+		Target:      []string{"DONE"},
+		Timeout:     time.Minute,
+		Refresh: func() (string, error) {
+			cancel()
+			return "WORKING", nil
+		},
+	}
+
+	err := waiter.wait(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the wait to be cancelled, got %v", err)
+	}
+}
+
+func TestRemainingTimeout(t *testing.T) {
+	if actual := remainingTimeout(context.Background(), time.Hour); actual != time.Hour {
+		t.Fatalf("expected the timeout without a deadline, got %s", actual)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if actual := remainingTimeout(ctx, time.Hour); actual > time.Minute || actual < 50*time.Second {
+		t.Fatalf("expected what is left of the deadline, got %s", actual)
+	}
+	if actual := remainingTimeout(ctx, time.Second); actual != time.Second {
+		t.Fatalf("expected the timeout when it ends before the deadline, got %s", actual)
+	}
+}
+
+func TestIsLastPage(t *testing.T) {
+	cases := []struct {
+		pageNumber, numberOfElements, totalPages int
+		lastPage                                 bool
+		expected                                 bool
+	}{
+		{0, 100, 3, false, false},
+		{2, 10, 3, false, true},
+		{1, 100, 3, true, true},
+		{4, 0, 0, false, true},
+	}
+
+	for _, tc := range cases {
+		if actual := isLastPage(tc.pageNumber, tc.numberOfElements, tc.totalPages, tc.lastPage); actual != tc.expected {
+			t.Errorf("isLastPage(%d, %d, %d, %t): expected %t", tc.pageNumber, tc.numberOfElements, tc.totalPages, tc.lastPage, tc.expected)
+		}
+	}
+}
+
+func TestLookupId(t *testing.T) {
+	if id, err := lookupId("git repo", "website", []string{"git-repo-1"}); err != nil || id != "git-repo-1" {
+		t.Fatalf("expected git-repo-1, got %q, %v", id, err)
+	}
+
+	if _, err := lookupId("git repo", "website", []string{}); err == nil || err.Error() != `no git repo named "website" found` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := lookupId("git repo", "website", []string{"git-repo-1", "git-repo-2"}); err == nil || err.Error() != `2 git repos named "website" found (git-repo-1, git-repo-2), set id to choose one` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNormalizeJson(t *testing.T) {
+	cases := map[string]string{
+		`{ "sourceRepo": "template", "sourceOwner": "xilution" }`: `{"sourceOwner":"xilution","sourceRepo":"template"}`,
+		"[1, 2,\n 3]": `[1,2,3]`,
+		"":            "",
+		"not json":    "not json",
+	}
+
+	for value, expected := range cases {
+		if actual := normalizeJson(value); actual != expected {
+			t.Errorf("normalizeJson(%q): expected %q, got %q", value, expected, actual)
+		}
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if d := jitter(10 * time.Second); d < 5*time.Second || d > 10*time.Second {
+			t.Fatalf("jitter(10s) = %s, expected between 5s and 10s", d)
+		}
+	}
+}
+
+func TestSetPipelineStatus(t *testing.T) {
+	d := resourceVpcPipeline().Data(nil)
+
+	if err := setPipelineStatus(d, &xc.PipelineStatus{InfrastructureStatus: CREATE_COMPLETE}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("infrastructure_status") != CREATE_COMPLETE || d.Get("latest_up_execution_status") != "" {
+		t.Fatalf("unexpected status attributes: %v, %v", d.Get("infrastructure_status"), d.Get("latest_up_execution_status"))
+	}
+
+	if err := setPipelineStatus(d, nil); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if d.Get("infrastructure_status") != "" {
+		t.Fatalf("expected a missing status to clear infrastructure_status, got %v", d.Get("infrastructure_status"))
+	}
+}
@@ -0,0 +1,448 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	xc "github.com/xilution/xilution-client-go"
+)
+
+const (
+	mockOrganizationId = "org-1"
+	mockClientId       = "client-1"
+	mockClientSecret   = "client-secret-1"
+	mockUserId         = "user-1"
+	mockAccessToken    = "mock-access-token"
+	mockOidcToken      = "mock-oidc-token"
+)
+
+// mockXilutionApi is a local HTTP server that speaks enough of the Xilution
+// API for the provider to run against it with base_url set to its URL. Every
+// product is served under /<product>, e.g. /gazelle/organizations/{org}/pipelines.
+type mockXilutionApi struct {
+	mu     sync.Mutex
+	nextId int
+
+	// documents are keyed by "<product>/<collection>" and then by id.
+	documents        map[string]map[string]map[string]interface{}
+	pipelineStatuses map[string][]xc.PipelineStatus
+
+	// failures are the responses the next requests are answered with
+	// instead of being served, to simulate API errors.
+	failures []mockFailure
+	requests int
+
+	// accessToken is the only token the mock API accepts. Tokens are issued
+	// with expiresIn seconds to live, or without an expiry when it is 0.
+	accessToken  string
+	expiresIn    int
+	tokensIssued int
+
+	server *httptest.Server
+}
+
+// newMockXilutionApi starts a mock Xilution API seeded with an organization,
+// a client and a user, and stops it when the test finishes.
+func newMockXilutionApi(t *testing.T) *mockXilutionApi {
+	api := &mockXilutionApi{
+		documents:        map[string]map[string]map[string]interface{}{},
+		pipelineStatuses: map[string][]xc.PipelineStatus{},
+		accessToken:      mockAccessToken,
+	}
+
+	api.seed("elephant", "organizations", map[string]interface{}{
+		"@type":  "Organization",
+		"id":     mockOrganizationId,
+		"name":   "Xilution",
+		"active": true,
+	})
+	api.seed("hippo", "clients", map[string]interface{}{
+		"@type":          "Client",
+		"id":             mockClientId,
+		"name":           "Terraform",
+		"clientUserId":   mockUserId,
+		"grants":         []string{"client_credentials"},
+		"redirectUris":   []string{},
+		"organizationId": mockOrganizationId,
+		"owningUserId":   mockUserId,
+		"active":         true,
+	})
+	api.seed("rhino", "users", map[string]interface{}{
+		"@type":          "User",
+		"id":             mockUserId,
+		"firstName":      "Test",
+		"lastName":       "User",
+		"email":          "test@example.com",
+		"username":       "test",
+		"organizationId": mockOrganizationId,
+		"active":         true,
+	})
+
+	api.server = httptest.NewServer(api)
+	t.Cleanup(api.server.Close)
+
+	return api
+}
+
+func (a *mockXilutionApi) URL() string {
+	return a.server.URL
+}
+
+func (a *mockXilutionApi) seed(product, collection string, document map[string]interface{}) {
+	a.collection(product, collection)[document["id"].(string)] = document
+}
+
+func (a *mockXilutionApi) collection(product, collection string) map[string]map[string]interface{} {
+	key := product + "/" + collection
+	if _, ok := a.documents[key]; !ok {
+		a.documents[key] = map[string]map[string]interface{}{}
+	}
+
+	return a.documents[key]
+}
+
+// exists reports whether the document is still held by the mock API.
+func (a *mockXilutionApi) exists(product, collection, id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, ok := a.collection(product, collection)[id]
+
+	return ok
+}
+
+// mockFailure is an error response the mock API answers a request with. A
+// nil body gets the status text as its message.
+type mockFailure struct {
+	statusCode int
+	body       []byte
+}
+
+// fail answers the next requests with the given status codes.
+func (a *mockXilutionApi) fail(statusCodes ...int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, statusCode := range statusCodes {
+		a.failures = append(a.failures, mockFailure{statusCode: statusCode})
+	}
+}
+
+// failWithBody answers the next request with the given status code and raw
+// body.
+func (a *mockXilutionApi) failWithBody(statusCode int, body string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.failures = append(a.failures, mockFailure{statusCode: statusCode, body: []byte(body)})
+}
+
+// revokeAccessToken makes the mock API reject the token it has handed out
+// so far and hand out a new one from then on.
+func (a *mockXilutionApi) revokeAccessToken() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.accessToken = fmt.Sprintf("%s-%d", mockAccessToken, a.tokensIssued)
+}
+
+// tokenCount returns how many access tokens the mock API has issued.
+func (a *mockXilutionApi) tokenCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.tokensIssued
+}
+
+// requestCount returns how many requests the mock API has received.
+func (a *mockXilutionApi) requestCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.requests
+}
+
+func (a *mockXilutionApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.requests++
+
+	if len(a.failures) > 0 {
+		failure := a.failures[0]
+		a.failures = a.failures[1:]
+		if failure.body == nil {
+			mockError(w, failure.statusCode, http.StatusText(failure.statusCode))
+		} else {
+			w.WriteHeader(failure.statusCode)
+			w.Write(failure.body)
+		}
+		return
+	}
+
+	// /<product>/organizations/<organization id>[/<collection>[/<id>]]
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[1] != "organizations" {
+		mockError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	product, organizationId := parts[0], parts[2]
+
+	if len(parts) == 5 && product == "zebra" && parts[3] == "oauth" && parts[4] == "token" && r.Method == http.MethodPost {
+		a.token(w, r, organizationId)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+a.accessToken {
+		mockError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && r.Method == http.MethodGet:
+		a.get(w, "elephant", "organizations", organizationId)
+	case len(parts) == 4 && r.Method == http.MethodPost:
+		a.create(w, r, product, parts[3], organizationId)
+	case len(parts) == 4 && r.Method == http.MethodGet:
+		a.list(w, r, product, parts[3])
+	case len(parts) == 5 && r.Method == http.MethodGet:
+		a.get(w, product, parts[3], parts[4])
+	case len(parts) == 5 && r.Method == http.MethodPut:
+		a.update(w, r, product, parts[3], parts[4])
+	case len(parts) == 5 && r.Method == http.MethodDelete:
+		a.delete(w, product, parts[3], parts[4])
+	default:
+		mockError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (a *mockXilutionApi) token(w http.ResponseWriter, r *http.Request, organizationId string) {
+	if err := r.ParseForm(); err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var authenticated bool
+	switch r.PostForm.Get("grant_type") {
+	case tokenExchangeGrantType:
+		authenticated = r.PostForm.Get("subject_token") == mockOidcToken && r.PostForm.Get("subject_token_type") == jwtTokenType
+	case jwtBearerGrantType:
+		authenticated = r.PostForm.Get("assertion") == mockOidcToken
+	default:
+		authenticated = r.PostForm.Get("client_id") == mockClientId && r.PostForm.Get("client_secret") == mockClientSecret
+	}
+
+	if organizationId != mockOrganizationId || !authenticated {
+		mockError(w, http.StatusUnauthorized, "Bad credentials")
+		return
+	}
+
+	a.tokensIssued++
+
+	response := map[string]interface{}{
+		"access_token": a.accessToken,
+	}
+	if a.expiresIn > 0 {
+		response["expires_in"] = a.expiresIn
+	}
+
+	mockJson(w, http.StatusOK, response)
+}
+
+func (a *mockXilutionApi) create(w http.ResponseWriter, r *http.Request, product, collection, organizationId string) {
+	document := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&document); err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch collection {
+	case "pipeline-events":
+		pipelineId, _ := document["pipelineId"].(string)
+		if _, ok := a.collection(product, "pipelines")[pipelineId]; !ok {
+			mockError(w, http.StatusBadRequest, fmt.Sprintf("pipeline %s does not exist", pipelineId))
+			return
+		}
+
+		eventType, _ := document["eventType"].(string)
+		transitions, err := fakePipelineStatusTransitions(eventType)
+		if err != nil {
+			mockError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		a.pipelineStatuses[pipelineId] = transitions
+	case "git-repo-events":
+		gitRepoId, _ := document["gitRepoId"].(string)
+		gitRepo, ok := a.collection(product, "git-repos")[gitRepoId]
+		if !ok {
+			mockError(w, http.StatusBadRequest, fmt.Sprintf("git repo %s does not exist", gitRepoId))
+			return
+		}
+		gitRepo["status"] = ACTIVE
+	}
+
+	a.nextId = a.nextId + 1
+	id := fmt.Sprintf("%032x", a.nextId)
+
+	document["id"] = id
+	document["createdAt"] = fakeTimestamp
+	document["modifiedAt"] = fakeTimestamp
+	a.collection(product, collection)[id] = document
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s/organizations/%s/%s/%s", a.URL(), product, organizationId, collection, id))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (a *mockXilutionApi) get(w http.ResponseWriter, product, collection, id string) {
+	document, ok := a.collection(product, collection)[id]
+	if !ok {
+		mockError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if collection == "pipelines" {
+		document = a.withPipelineStatus(document, true)
+	}
+
+	mockJson(w, http.StatusOK, document)
+}
+
+func (a *mockXilutionApi) list(w http.ResponseWriter, r *http.Request, product, collection string) {
+	pageSize, err := strconv.Atoi(r.URL.Query().Get("pageSize"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+	pageNumber, err := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+	if err != nil || pageNumber < 0 {
+		pageNumber = 0
+	}
+
+	documents := a.collection(product, collection)
+	ids := make([]string, 0, len(documents))
+	for id := range documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	content := []map[string]interface{}{}
+	for i := pageNumber * pageSize; i < len(ids) && i < (pageNumber+1)*pageSize; i++ {
+		document := documents[ids[i]]
+		if collection == "pipelines" {
+			document = a.withPipelineStatus(document, false)
+		}
+		content = append(content, document)
+	}
+
+	totalPages := (len(ids) + pageSize - 1) / pageSize
+
+	mockJson(w, http.StatusOK, map[string]interface{}{
+		"content":          content,
+		"pageSize":         pageSize,
+		"pageNumber":       pageNumber,
+		"totalPages":       totalPages,
+		"numberOfElements": len(content),
+		"totalElements":    len(ids),
+		"firstPage":        pageNumber == 0,
+		"lastPage":         pageNumber >= totalPages-1,
+	})
+}
+
+// withPipelineStatus copies a pipeline with its current status. Reading a
+// single pipeline moves it one step along the transitions started by its
+// latest pipeline event, the way fakeXilutionClient does.
+func (a *mockXilutionApi) withPipelineStatus(pipeline map[string]interface{}, advance bool) map[string]interface{} {
+	copied := map[string]interface{}{}
+	for key, value := range pipeline {
+		copied[key] = value
+	}
+
+	id := pipeline["id"].(string)
+	statuses := a.pipelineStatuses[id]
+	if len(statuses) == 0 {
+		copied["status"] = fakeStatus(NOT_FOUND, "")
+		return copied
+	}
+
+	copied["status"] = statuses[0]
+	if advance && len(statuses) > 1 {
+		a.pipelineStatuses[id] = statuses[1:]
+	}
+
+	return copied
+}
+
+func (a *mockXilutionApi) update(w http.ResponseWriter, r *http.Request, product, collection, id string) {
+	existing, ok := a.collection(product, collection)[id]
+	if !ok {
+		mockError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	document := map[string]interface{}{}
+	if err := json.NewDecoder(r.Body).Decode(&document); err != nil {
+		mockError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	document["id"] = id
+	document["createdAt"] = existing["createdAt"]
+	document["modifiedAt"] = fakeTimestamp
+	a.collection(product, collection)[id] = document
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *mockXilutionApi) delete(w http.ResponseWriter, product, collection, id string) {
+	if _, ok := a.collection(product, collection)[id]; !ok {
+		mockError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	delete(a.collection(product, collection), id)
+	delete(a.pipelineStatuses, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func mockJson(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+func mockError(w http.ResponseWriter, statusCode int, message string) {
+	mockJson(w, statusCode, xc.ErrorResponse{
+		Message: message,
+	})
+}
+
+// pipelineInfrastructureStatus returns the infrastructure status a pipeline
+// currently reports without moving it along its transitions.
+func (a *mockXilutionApi) pipelineInfrastructureStatus(pipelineId string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	statuses := a.pipelineStatuses[pipelineId]
+	if len(statuses) == 0 {
+		return NOT_FOUND
+	}
+
+	return statuses[0].InfrastructureStatus
+}
+
+// status returns the status field of a document held by the mock API.
+func (a *mockXilutionApi) status(product, collection, id string) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	status, _ := a.collection(product, collection)[id]["status"].(string)
+
+	return status
+}
@@ -2,10 +2,15 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/xilution/xilution-client-go"
 )
 
@@ -13,6 +18,16 @@ import (
 func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_PROFILE", nil),
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_SHARED_CREDENTIALS_FILE", nil),
+			},
 			"organization_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -21,12 +36,12 @@ func Provider() *schema.Provider {
 			"grant_type": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("XILUTION_GRANT_TYPE", "client_credentials"),
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_GRANT_TYPE", nil),
 			},
 			"scope": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("XILUTION_SCOPE", "read write"),
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_SCOPE", nil),
 			},
 			"client_id": {
 				Type:        schema.TypeString,
@@ -50,26 +65,128 @@ func Provider() *schema.Provider {
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("XILUTION_PASSWORD", nil),
 			},
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_ACCESS_TOKEN", nil),
+			},
+			"oidc_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_OIDC_TOKEN", nil),
+			},
+			"oidc_token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_OIDC_TOKEN_FILE", nil),
+			},
+			"owning_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_OWNING_USER_ID", nil),
+			},
+			// base_url serves every Xilution API, auth included, from one
+			// endpoint. An endpoints setting, or its XILUTION_*_ENDPOINT
+			// variable, takes precedence over it for that endpoint.
+			"base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_BASE_URL", nil),
+			},
+			"token_cache_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_TOKEN_CACHE_FILE", nil),
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"auth": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"api": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_INSECURE_SKIP_VERIFY", false),
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_CA_BUNDLE", nil),
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("XILUTION_PROXY_URL", nil),
+			},
+			"max_retries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("XILUTION_MAX_RETRIES", 4),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"retry_wait_min": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("XILUTION_RETRY_WAIT_MIN", "1s"),
+				ValidateDiagFunc: validateDuration,
+			},
+			"retry_wait_max": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("XILUTION_RETRY_WAIT_MAX", "30s"),
+				ValidateDiagFunc: validateDuration,
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"xilution_organization":                  dataSourceOrganization(),
-			"xilution_client":                        dataSourceClient(),
-			"xilution_user":                          dataSourceUser(),
-			"xilution_git_account":                   dataSourceGitAccount(),
-			"xilution_git_repo":                      dataSourceGitRepo(),
-			"xilution_git_repo_event":                dataSourceGitRepoEvent(),
-			"xilution_cloud_provider":                dataSourceCloudProvider(),
-			"xilution_vpc_pipeline":                  dataSourceVpcPipeline(),
-			"xilution_vpc_pipeline_event":            dataSourceVpcPipelineEvent(),
-			"xilution_k8s_pipeline":                  dataSourceK8sPipeline(),
-			"xilution_k8s_pipeline_event":            dataSourceK8sPipelineEvent(),
-			"xilution_word_press_pipeline":           dataSourceWordPressPipeline(),
-			"xilution_word_press_pipeline_event":     dataSourceWordPressPipelineEvent(),
-			"xilution_static_content_pipeline":       dataSourceStaticContentPipeline(),
-			"xilution_static_content_pipeline_event": dataSourceStaticContentPipelineEvent(),
-			"xilution_api_pipeline":                  dataSourceApiPipeline(),
-			"xilution_api_pipeline_event":            dataSourceApiPipelineEvent(),
-			"xilution_pipeline_prototype":            dataSourcePipelinePrototype(),
+			"xilution_organization":                   dataSourceOrganization(),
+			"xilution_client":                         dataSourceClient(),
+			"xilution_clients":                        dataSourceClients(),
+			"xilution_user":                           dataSourceUser(),
+			"xilution_users":                          dataSourceUsers(),
+			"xilution_git_account":                    dataSourceGitAccount(),
+			"xilution_git_accounts":                   dataSourceGitAccounts(),
+			"xilution_git_repo":                       dataSourceGitRepo(),
+			"xilution_git_repos":                      dataSourceGitRepos(),
+			"xilution_git_repo_event":                 dataSourceGitRepoEvent(),
+			"xilution_git_repo_events":                dataSourceGitRepoEvents(),
+			"xilution_cloud_provider":                 dataSourceCloudProvider(),
+			"xilution_cloud_providers":                dataSourceCloudProviders(),
+			"xilution_vpc_pipeline":                   dataSourceVpcPipeline(),
+			"xilution_vpc_pipelines":                  dataSourceVpcPipelines(),
+			"xilution_vpc_pipeline_event":             dataSourceVpcPipelineEvent(),
+			"xilution_vpc_pipeline_events":            dataSourceVpcPipelineEvents(),
+			"xilution_k8s_pipeline":                   dataSourceK8sPipeline(),
+			"xilution_k8s_pipelines":                  dataSourceK8sPipelines(),
+			"xilution_k8s_pipeline_event":             dataSourceK8sPipelineEvent(),
+			"xilution_k8s_pipeline_events":            dataSourceK8sPipelineEvents(),
+			"xilution_word_press_pipeline":            dataSourceWordPressPipeline(),
+			"xilution_word_press_pipelines":           dataSourceWordPressPipelines(),
+			"xilution_word_press_pipeline_event":      dataSourceWordPressPipelineEvent(),
+			"xilution_word_press_pipeline_events":     dataSourceWordPressPipelineEvents(),
+			"xilution_static_content_pipeline":        dataSourceStaticContentPipeline(),
+			"xilution_static_content_pipelines":       dataSourceStaticContentPipelines(),
+			"xilution_static_content_pipeline_event":  dataSourceStaticContentPipelineEvent(),
+			"xilution_static_content_pipeline_events": dataSourceStaticContentPipelineEvents(),
+			"xilution_api_pipeline":                   dataSourceApiPipeline(),
+			"xilution_api_pipelines":                  dataSourceApiPipelines(),
+			"xilution_api_pipeline_event":             dataSourceApiPipelineEvent(),
+			"xilution_api_pipeline_events":            dataSourceApiPipelineEvents(),
+			"xilution_pipeline_prototype":             dataSourcePipelinePrototype(),
+			"xilution_pipeline_prototypes":            dataSourcePipelinePrototypes(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"xilution_git_account":                   resourceGitAccount(),
@@ -95,27 +212,266 @@ func Provider() *schema.Provider {
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	log.Println("[INFO] Configuring Xilution Provider")
 
-	organizationId := d.Get("organization_id").(string)
-	grantType := d.Get("grant_type").(string)
-	scope := d.Get("scope").(string)
-	clientId := d.Get("client_id").(string)
-	clientSecret := d.Get("client_secret").(string)
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	sharedCredentials, err := loadSharedCredentials(d.Get("shared_credentials_file").(string), d.Get("profile").(string))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid Xilution shared credentials",
+			Detail:   err.Error(),
+		})
+
+		return nil, diags
+	}
+
+	organizationId := providerSetting(d.Get("organization_id").(string), sharedCredentials, "organization_id", "")
+	staticAccessToken := d.Get("access_token").(string)
+	oidcToken := d.Get("oidc_token").(string)
+	oidcTokenFile := d.Get("oidc_token_file").(string)
+	grantType := providerSetting(d.Get("grant_type").(string), sharedCredentials, "grant_type", "client_credentials")
+	if oidcToken != "" || oidcTokenFile != "" {
+		// A profile's grant_type is meant for its client credentials, so only
+		// a grant_type set on the provider can choose the JWT bearer grant.
+		grantType = d.Get("grant_type").(string)
+		if grantType == "" {
+			grantType = tokenExchangeGrantType
+		}
+
+		if grantType != tokenExchangeGrantType && grantType != jwtBearerGrantType {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid Xilution grant type",
+				Detail:   fmt.Sprintf("grant_type %s cannot exchange an OIDC token, use %s or %s", grantType, tokenExchangeGrantType, jwtBearerGrantType),
+			})
+
+			return nil, diags
+		}
+	}
+	scope := providerSetting(d.Get("scope").(string), sharedCredentials, "scope", "read write")
+	clientId := providerSetting(d.Get("client_id").(string), sharedCredentials, "client_id", "")
+	clientSecret := providerSetting(d.Get("client_secret").(string), sharedCredentials, "client_secret", "")
 	username := d.Get("username").(string)
 	password := d.Get("password").(string)
+	owningUserId := d.Get("owning_user_id").(string)
+	baseUrl := d.Get("base_url").(string)
+	apiEndpoint := providerEndpoint(d, "api", "XILUTION_API_ENDPOINT", baseUrl)
+	authEndpoint := providerEndpoint(d, "auth", "XILUTION_AUTH_ENDPOINT", baseUrl)
+	insecureSkipVerify := d.Get("insecure_skip_verify").(bool)
+	caBundle := d.Get("ca_bundle").(string)
+	proxyUrl := d.Get("proxy_url").(string)
+	maxRetries := d.Get("max_retries").(int)
+	retryWaitMin, _ := time.ParseDuration(d.Get("retry_wait_min").(string))
+	retryWaitMax, _ := time.ParseDuration(d.Get("retry_wait_max").(string))
+	tokenCacheFile := d.Get("token_cache_file").(string)
 
-	// Warning or errors can be collected in a slice type
-	var diags diag.Diagnostics
+	// The authenticated user is looked up through the client or the username,
+	// neither of which an access token or an OIDC token comes with.
+	if owningUserId == "" && (staticAccessToken != "" || oidcToken != "" || oidcTokenFile != "") {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Xilution owning user id",
+			Detail:   "owning_user_id must be set on the provider when authenticating with access_token, oidc_token or oidc_token_file, since the authenticated user cannot be looked up.",
+		})
+
+		return nil, diags
+	}
+
+	if retryWaitMin > retryWaitMax {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid Xilution retry settings",
+			Detail:   fmt.Sprintf("retry_wait_min (%s) must not be longer than retry_wait_max (%s)", retryWaitMin, retryWaitMax),
+		})
+
+		return nil, diags
+	}
 
-	xc, err := xilution.NewXilutionClient(&organizationId, &grantType, &scope, &clientId, &clientSecret, &username, &password)
+	if insecureSkipVerify {
+		log.Printf("[WARN] insecure_skip_verify is set, Xilution TLS certificates will not be verified")
+	}
+
+	transport, err := newHttpTransport(insecureSkipVerify, caBundle, proxyUrl)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid Xilution connection settings",
+			Detail:   err.Error(),
+		})
+
+		return nil, diags
+	}
+
+	httpClient, err := newHttpClient(apiEndpoint, authEndpoint, newRetryableHttpClient(transport, maxRetries, retryWaitMin, retryWaitMax))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Invalid Xilution endpoint",
+			Detail:   err.Error(),
+		})
+
+		return nil, diags
+	}
+
+	tokens := &tokenSource{}
+
+	switch {
+	case staticAccessToken != "":
+		log.Printf("[DEBUG] Authenticating with the configured Xilution access token")
+		tokens.token = newAccessToken(staticAccessToken, 0)
+	case oidcToken != "" || oidcTokenFile != "":
+		log.Printf("[DEBUG] Authenticating by exchanging an OIDC token with the %s grant", grantType)
+		tokens.fetch = func() (*accessToken, error) {
+			token, err := readOidcToken(oidcToken, oidcTokenFile)
+			if err != nil {
+				return nil, err
+			}
+			return requestAccessToken(httpClient, organizationId, oidcGrant(grantType, scope, clientId, token))
+		}
+	default:
+		tokens.fetch = func() (*accessToken, error) {
+			return requestAccessToken(httpClient, organizationId, credentialsGrant(grantType, scope, clientId, clientSecret, username, password))
+		}
+	}
+
+	if tokenCacheFile != "" && tokens.fetch != nil {
+		path, err := expandHomeDir(tokenCacheFile)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Invalid Xilution token cache file",
+				Detail:   err.Error(),
+			})
+
+			return nil, diags
+		}
+
+		tokens.cache = &tokenCache{path: path}
+		tokens.cacheKey = tokenCacheKey(apiEndpoint, authEndpoint, organizationId, grantType, scope, clientId, username)
+	}
+
+	token, err := tokens.accessToken()
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  "Unable to create Xilution client",
-			Detail:   "Unable to auth user for authenticated Xilution client",
+			Detail:   "Unable to auth user for authenticated Xilution client: " + err.Error(),
 		})
 
 		return nil, diags
 	}
 
-	return xc, diags
+	xc, err := xilution.NewXilutionClientWithToken(&token)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Unable to create Xilution client",
+			Detail:   err.Error(),
+		})
+
+		return nil, diags
+	}
+	xc.HttpClient = &authHttpClient{
+		httpClient: httpClient,
+		tokens:     tokens,
+	}
+
+	return &providerMeta{
+		xilutionClient: xc,
+		organizationId: organizationId,
+		owningUserId:   owningUserId,
+		grantType:      grantType,
+		clientId:       clientId,
+		username:       username,
+	}, diags
+}
+
+// providerEndpoint returns an endpoints block setting, falling back to its
+// environment variable and then to base_url, so base_url only applies to the
+// endpoints that are not set on their own.
+func providerEndpoint(d *schema.ResourceData, key string, envVar string, baseUrl string) string {
+	if endpoint, ok := d.GetOk("endpoints.0." + key); ok {
+		return endpoint.(string)
+	}
+
+	if endpoint := os.Getenv(envVar); endpoint != "" {
+		return endpoint
+	}
+
+	return baseUrl
+}
+
+// providerMeta is the meta handed to every resource and data source. It is the
+// Xilution client plus the defaults resources fall back to when they leave
+// organization_id or owning_user_id unset.
+type providerMeta struct {
+	xilutionClient
+
+	organizationId string
+	owningUserId   string
+
+	grantType string
+	clientId  string
+	username  string
+
+	mu sync.Mutex
+}
+
+// defaultOwningUserId returns the provider's owning_user_id or, when that is
+// not set, the id of the user the provider authenticated as.
+func (p *providerMeta) defaultOwningUserId() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.owningUserId != "" {
+		return p.owningUserId, nil
+	}
+
+	if p.organizationId == "" {
+		return "", fmt.Errorf("owning_user_id must be set on the resource or the provider")
+	}
+
+	switch p.grantType {
+	case "client_credentials":
+		client, err := p.GetClient(&p.organizationId, &p.clientId)
+		if err != nil {
+			return "", fmt.Errorf("unable to look up the user of client %s: %w", p.clientId, err)
+		}
+		p.owningUserId = client.ClientUserId
+	case "password":
+		userId, err := p.findUserIdByUsername(p.username)
+		if err != nil {
+			return "", err
+		}
+		p.owningUserId = userId
+	}
+
+	if p.owningUserId == "" {
+		return "", fmt.Errorf("unable to determine the authenticated user, set owning_user_id on the resource or the provider")
+	}
+
+	log.Printf("[DEBUG] Defaulting owning_user_id to the authenticated user %s", p.owningUserId)
+
+	return p.owningUserId, nil
+}
+
+func (p *providerMeta) findUserIdByUsername(username string) (string, error) {
+	pageSize := 100
+	for pageNumber := 0; ; pageNumber++ {
+		users, err := p.GetUsers(&p.organizationId, &pageSize, &pageNumber)
+		if err != nil {
+			return "", fmt.Errorf("unable to look up user %s: %w", username, err)
+		}
+
+		for _, user := range users.Content {
+			if user.Username == username {
+				return user.ID, nil
+			}
+		}
+
+		if users.LastPage || len(users.Content) == 0 {
+			return "", fmt.Errorf("unable to find user %s", username)
+		}
+	}
 }
@@ -0,0 +1,670 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestProvider_validation(t *testing.T) {
+	cases := []struct {
+		resource  string
+		attribute string
+		valid     string
+		invalid   string
+	}{
+		{"xilution_vpc_pipeline", "pipeline_type", "AWS_SMALL", "AWS_TINY"},
+		{"xilution_k8s_pipeline", "pipeline_type", "AWS_SMALL", "aws_small"},
+		{"xilution_word_press_pipeline", "pipeline_type", "AWS_SMALL", "AWS_LARGE"},
+		{"xilution_static_content_pipeline", "pipeline_type", "AWS_SMALL", "SMALL"},
+		{"xilution_api_pipeline", "pipeline_type", "AWS_SMALL", "AWS-SMALL"},
+		{"xilution_vpc_pipeline_event", "event_type", "PROVISION", "PROVISON"},
+		{"xilution_k8s_pipeline_event", "event_type", "REPROVISION", "provision"},
+		{"xilution_word_press_pipeline_event", "event_type", "DEPROVISION", "DESTROY"},
+		{"xilution_static_content_pipeline_event", "event_type", "RUN_NOW", "RUN"},
+		{"xilution_api_pipeline_event", "event_type", "PROVISION", "DEPLOY"},
+		{"xilution_git_repo_event", "event_type", "CREATE_REPO_FROM_TEMPLATE_REPO", "CREATE_REPO"},
+		{"xilution_git_account", "git_provider", "GIT_HUB", "GITHUB"},
+		{"xilution_cloud_provider", "cloud_provider", "AWS", "GCP"},
+		{"xilution_cloud_provider", "account_id", "123456789012", "12345"},
+		{"xilution_cloud_provider", "region", "us-east-1", "us-east"},
+		{"xilution_cloud_provider", "region", "us-gov-west-1", "US-EAST-1"},
+	}
+
+	resources := Provider().ResourcesMap
+	for _, c := range cases {
+		validateDiagFunc := resources[c.resource].Schema[c.attribute].ValidateDiagFunc
+		if validateDiagFunc == nil {
+			t.Errorf("%s.%s is not validated", c.resource, c.attribute)
+			continue
+		}
+
+		if diags := validateDiagFunc(c.valid, cty.GetAttrPath(c.attribute)); diags.HasError() {
+			t.Errorf("%s.%s: expected %q to be valid, got %v", c.resource, c.attribute, c.valid, diags)
+		}
+		if diags := validateDiagFunc(c.invalid, cty.GetAttrPath(c.attribute)); !diags.HasError() {
+			t.Errorf("%s.%s: expected %q to be invalid", c.resource, c.attribute, c.invalid)
+		}
+	}
+}
+
+func TestProvider_baseUrl(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":        api.URL(),
+		"organization_id": mockOrganizationId,
+		"client_id":       mockClientId,
+		"client_secret":   mockClientSecret,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configure: %v", diags)
+	}
+
+	r := newTestResource(t, resourceVpcPipeline(), p.Meta())
+
+	state := r.apply(testVpcPipelineConfig("VPC 1"))
+	if !api.exists("gazelle", "pipelines", state.ID) {
+		t.Fatalf("expected the pipeline %s to be created through the mock api", state.ID)
+	}
+
+	state = r.apply(testVpcPipelineConfig("VPC 2"))
+	if state.Attributes["name"] != "VPC 2" {
+		t.Fatalf("expected the pipeline to be renamed, got %v", state.Attributes)
+	}
+
+	r.destroy()
+	if api.exists("gazelle", "pipelines", state.ID) {
+		t.Fatal("expected the pipeline to be deleted")
+	}
+}
+
+func TestProvider_notFound(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":        api.URL(),
+		"organization_id": mockOrganizationId,
+		"client_id":       mockClientId,
+		"client_secret":   mockClientSecret,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configure: %v", diags)
+	}
+
+	r := newTestResource(t, resourceVpcPipeline(), p.Meta())
+	r.apply(testVpcPipelineConfig("VPC 1"))
+
+	api.failWithBody(http.StatusBadRequest, `{"message":"git repo abc not found"}`)
+	if _, diags := r.resource.RefreshWithoutUpgrade(context.Background(), r.state, r.meta); !diags.HasError() {
+		t.Fatal("expected a 400 mentioning not found to fail the refresh")
+	}
+
+	api.failWithBody(http.StatusNotFound, "")
+	if state := r.refresh(); state != nil {
+		t.Fatalf("expected a bare 404 to remove the pipeline from state, got %v", state)
+	}
+}
+
+func TestProvider_retries(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":        api.URL(),
+		"organization_id": mockOrganizationId,
+		"client_id":       mockClientId,
+		"client_secret":   mockClientSecret,
+		"max_retries":     2,
+		"retry_wait_min":  "1ms",
+		"retry_wait_max":  "1ms",
+	}))
+	if diags.HasError() {
+		t.Fatalf("configure: %v", diags)
+	}
+
+	c := p.Meta().(*providerMeta)
+	organizationId := mockOrganizationId
+
+	api.fail(http.StatusServiceUnavailable, http.StatusBadGateway)
+	requests := api.requestCount()
+	if _, err := c.GetOrganization(&organizationId); err != nil {
+		t.Fatalf("expected transient errors to be retried, got %s", err)
+	}
+	if retries := api.requestCount() - requests - 1; retries != 2 {
+		t.Fatalf("expected 2 retries, got %d", retries)
+	}
+
+	api.fail(http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	if _, err := c.GetOrganization(&organizationId); err == nil || err.Error() != "Bad Gateway" {
+		t.Fatalf("expected the last error to surface once retries ran out, got %v", err)
+	}
+
+	api.fail(http.StatusBadGateway)
+	requests = api.requestCount()
+	if _, err := c.CreateGitAccount(&organizationId, &xc.GitAccount{Name: "xilution"}); err == nil {
+		t.Fatal("expected a POST that may have been handled not to be retried")
+	}
+	if api.requestCount()-requests != 1 {
+		t.Fatalf("expected a single request, got %d", api.requestCount()-requests)
+	}
+}
+
+func TestProvider_invalidRetryWaits(t *testing.T) {
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"retry_wait_min": "1m",
+		"retry_wait_max": "1s",
+	}))
+	if !diags.HasError() {
+		t.Fatal("expected retry_wait_min longer than retry_wait_max to be rejected")
+	}
+}
+
+func TestCheckRetry(t *testing.T) {
+	cases := []struct {
+		method     string
+		statusCode int
+		err        error
+		retry      bool
+	}{
+		{http.MethodGet, http.StatusOK, nil, false},
+		{http.MethodGet, http.StatusNotFound, nil, false},
+		{http.MethodGet, http.StatusUnauthorized, nil, false},
+		{http.MethodGet, http.StatusTooManyRequests, nil, true},
+		{http.MethodGet, http.StatusInternalServerError, nil, true},
+		{http.MethodGet, http.StatusNotImplemented, nil, false},
+		{http.MethodGet, http.StatusGatewayTimeout, nil, true},
+		{http.MethodPut, http.StatusBadGateway, nil, true},
+		{http.MethodPost, http.StatusBadGateway, nil, false},
+		{http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{http.MethodPost, http.StatusServiceUnavailable, nil, true},
+		{http.MethodGet, 0, &url.Error{Op: "Get", Err: io.ErrUnexpectedEOF}, true},
+		{http.MethodPost, 0, &url.Error{Op: "Post", Err: io.ErrUnexpectedEOF}, false},
+		{http.MethodPost, 0, &url.Error{Op: "Post", Err: &net.OpError{Op: "dial", Err: io.EOF}}, true},
+		{http.MethodGet, 0, &url.Error{Op: "Get", Err: x509.UnknownAuthorityError{}}, false},
+	}
+
+	for _, c := range cases {
+		var resp *http.Response
+		if c.err == nil {
+			resp = &http.Response{
+				StatusCode: c.statusCode,
+				Request:    &http.Request{Method: c.method},
+			}
+		}
+
+		retry, _ := checkRetry(context.Background(), resp, c.err)
+		if retry != c.retry {
+			t.Errorf("%s %d %v: expected retry to be %t", c.method, c.statusCode, c.err, c.retry)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if retry, _ := checkRetry(ctx, nil, io.EOF); retry {
+		t.Error("expected a cancelled request not to be retried")
+	}
+}
+
+func TestProvider_badCredentials(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":        api.URL(),
+		"organization_id": mockOrganizationId,
+		"client_id":       mockClientId,
+		"client_secret":   "wrong",
+	}))
+	if !diags.HasError() {
+		t.Fatal("expected configuring with bad credentials to fail")
+	}
+}
+
+func TestProvider_invalidBaseUrl(t *testing.T) {
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url": "localhost:8080",
+	}))
+	if !diags.HasError() {
+		t.Fatal("expected a relative base url to be rejected")
+	}
+}
+
+func TestProvider_endpoints(t *testing.T) {
+	auth := newMockXilutionApi(t)
+	api := newMockXilutionApi(t)
+
+	c := testConfigureProvider(t, api, map[string]interface{}{
+		"base_url": "https://xilution.invalid",
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"auth": auth.URL(),
+				"api":  api.URL(),
+			},
+		},
+	})
+
+	if tokens := auth.tokenCount(); tokens != 1 {
+		t.Fatalf("expected the token to be requested from the auth endpoint, %d tokens were issued", tokens)
+	}
+
+	organizationId := mockOrganizationId
+	if _, err := c.GetOrganization(&organizationId); err != nil {
+		t.Fatalf("expected organizations to be read through the api endpoint, got %s", err)
+	}
+	if tokens := api.tokenCount(); tokens != 0 {
+		t.Fatalf("expected no tokens to be requested from the api endpoint, %d tokens were issued", tokens)
+	}
+
+	testSetenv(t, "XILUTION_AUTH_ENDPOINT", auth.URL())
+	testSetenv(t, "XILUTION_API_ENDPOINT", api.URL())
+	testConfigureProvider(t, api, map[string]interface{}{
+		"base_url": "https://xilution.invalid",
+	})
+	if tokens := auth.tokenCount(); tokens != 2 {
+		t.Fatalf("expected XILUTION_AUTH_ENDPOINT to choose the auth endpoint, %d tokens were issued", tokens)
+	}
+}
+
+func TestProvider_endpointsOverrideBaseUrl(t *testing.T) {
+	base := newMockXilutionApi(t)
+	api := newMockXilutionApi(t)
+
+	c := testConfigureProvider(t, base, map[string]interface{}{
+		"endpoints": []interface{}{
+			map[string]interface{}{
+				"api": api.URL(),
+			},
+		},
+	})
+
+	if tokens := base.tokenCount(); tokens != 1 {
+		t.Fatalf("expected base_url to serve the auth endpoint that is not set, %d tokens were issued", tokens)
+	}
+
+	requests := base.requestCount()
+	organizationId := mockOrganizationId
+	if _, err := c.GetOrganization(&organizationId); err != nil {
+		t.Fatalf("expected organizations to be read through endpoints.api, got %s", err)
+	}
+	if base.requestCount() != requests {
+		t.Fatal("expected endpoints.api to take precedence over base_url")
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	cases := []struct {
+		endpoint    string
+		product     string
+		url         string
+		productPath string
+	}{
+		{"https://{product}.staging.xilution.com", "gazelle", "https://gazelle.staging.xilution.com", ""},
+		{"http://localhost:8080/xilution", "zebra", "http://localhost:8080/xilution", "zebra"},
+	}
+
+	for _, tc := range cases {
+		baseUrl, productPath, err := resolveEndpoint(tc.endpoint, tc.product)
+		if err != nil {
+			t.Fatalf("%s: err: %s", tc.endpoint, err)
+		}
+		if baseUrl.String() != tc.url || productPath != tc.productPath {
+			t.Errorf("%s: expected %s and %q, got %s and %q", tc.endpoint, tc.url, tc.productPath, baseUrl, productPath)
+		}
+	}
+
+	if _, _, err := resolveEndpoint("{product}.xilution.com", "zebra"); err == nil {
+		t.Fatal("expected a relative endpoint to be rejected")
+	}
+}
+
+func TestProvider_caBundle(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	server := httptest.NewTLSServer(api)
+	t.Cleanup(server.Close)
+
+	caBundle := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := ioutil.WriteFile(caBundle, certificate, 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	config := map[string]interface{}{
+		"base_url":        server.URL,
+		"organization_id": mockOrganizationId,
+		"client_id":       mockClientId,
+		"client_secret":   mockClientSecret,
+		"max_retries":     0,
+	}
+
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Fatal("expected a certificate from an unknown authority to be rejected")
+	}
+
+	config["ca_bundle"] = caBundle
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("expected the ca_bundle to be trusted, got %v", diags)
+	}
+
+	delete(config, "ca_bundle")
+	config["insecure_skip_verify"] = true
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("expected insecure_skip_verify to accept the certificate, got %v", diags)
+	}
+
+	config["ca_bundle"] = filepath.Join(t.TempDir(), "missing.pem")
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Fatal("expected a missing ca_bundle to be an error")
+	}
+}
+
+func TestProvider_proxyUrl(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+
+		req := r.Clone(r.Context())
+		req.RequestURI = ""
+		resp, err := http.DefaultTransport.RoundTrip(req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+
+		for k, v := range resp.Header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+	t.Cleanup(proxy.Close)
+
+	testConfigureProvider(t, api, map[string]interface{}{
+		"proxy_url": proxy.URL,
+	})
+	if atomic.LoadInt32(&proxied) == 0 {
+		t.Fatal("expected requests to be sent through the proxy")
+	}
+
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":  api.URL(),
+		"proxy_url": "proxy.example.com:3128",
+	}))
+	if !diags.HasError() {
+		t.Fatal("expected a relative proxy url to be rejected")
+	}
+}
+
+func TestProviderMeta_defaultOwningUserId(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.users["user-1"] = &xc.User{ID: "user-1", Username: "someone"}
+	c.users["user-2"] = &xc.User{ID: "user-2", Username: "me"}
+
+	meta := &providerMeta{
+		xilutionClient: c,
+		organizationId: "org-1",
+		grantType:      "password",
+		username:       "me",
+	}
+
+	owningUserId, err := meta.defaultOwningUserId()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if owningUserId != "user-2" {
+		t.Fatalf("owning user id = %s, expected user-2", owningUserId)
+	}
+
+	meta = &providerMeta{
+		xilutionClient: c,
+		organizationId: "org-1",
+		owningUserId:   "user-1",
+		grantType:      "password",
+		username:       "me",
+	}
+	if owningUserId, _ := meta.defaultOwningUserId(); owningUserId != "user-1" {
+		t.Fatalf("owning user id = %s, expected the provider's owning_user_id", owningUserId)
+	}
+
+	meta = &providerMeta{
+		xilutionClient: c,
+		organizationId: "org-1",
+		grantType:      "password",
+		username:       "nobody",
+	}
+	if _, err := meta.defaultOwningUserId(); err == nil {
+		t.Fatal("expected an unknown username to fail")
+	}
+}
+
+// testAccProviderFactories serves the provider in-process to the Terraform
+// CLI that acceptance tests run.
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"xilution": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+// testAccProviderConfig points the provider at a mock Xilution API.
+func testAccProviderConfig(api *mockXilutionApi) string {
+	return fmt.Sprintf(`
+provider "xilution" {
+  base_url        = %q
+  organization_id = %q
+  client_id       = %q
+  client_secret   = %q
+}
+`, api.URL(), mockOrganizationId, mockClientId, mockClientSecret)
+}
+
+// testAccImportStateIdFunc builds the <organization_id>/<id> import id of a
+// resource in state.
+func testAccImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["organization_id"], rs.Primary.ID), nil
+	}
+}
+
+// testAccCheckDestroy verifies every resource of the given type was deleted
+// from the mock Xilution API.
+func testAccCheckDestroy(api *mockXilutionApi, resourceType, product, collection string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			if api.exists(product, collection, rs.Primary.ID) {
+				return fmt.Errorf("%s (%s) still exists", resourceType, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckPipelineInfrastructureStatus verifies the infrastructure status
+// the mock Xilution API reports for a pipeline in state.
+func testAccCheckPipelineInfrastructureStatus(api *mockXilutionApi, resourceName, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		if actual := api.pipelineInfrastructureStatus(rs.Primary.ID); actual != expected {
+			return fmt.Errorf("%s infrastructure status is %s, expected %s", resourceName, actual, expected)
+		}
+
+		return nil
+	}
+}
+
+// testProviderMeta wraps a client the way providerConfigure does, without any
+// provider level defaults.
+func testProviderMeta(c xilutionClient) *providerMeta {
+	return &providerMeta{
+		xilutionClient: c,
+	}
+}
+
+// testShortenWaits makes every stateWaiter poll in milliseconds for the
+// duration of a test.
+func testShortenWaits(t *testing.T) {
+	delay, minInterval, maxInterval := waitDelay, waitMinInterval, waitMaxInterval
+	waitDelay, waitMinInterval, waitMaxInterval = time.Millisecond, time.Millisecond, 5*time.Millisecond
+
+	t.Cleanup(func() {
+		waitDelay, waitMinInterval, waitMaxInterval = delay, minInterval, maxInterval
+	})
+}
+
+// testResource drives a resource through plan, apply, refresh, import and
+// destroy in-process, the way Terraform core would, against the given meta.
+// Data sources are read with readData.
+type testResource struct {
+	t        *testing.T
+	resource *schema.Resource
+	meta     interface{}
+	state    *terraform.InstanceState
+}
+
+func newTestResource(t *testing.T, resource *schema.Resource, meta interface{}) *testResource {
+	return &testResource{
+		t:        t,
+		resource: resource,
+		meta:     meta,
+	}
+}
+
+func (r *testResource) plan(raw map[string]interface{}) *terraform.InstanceDiff {
+	r.t.Helper()
+
+	config := terraform.NewResourceConfigRaw(raw)
+	if diags := r.resource.Validate(config); diags.HasError() {
+		r.t.Fatalf("validate: %v", diags)
+	}
+
+	diff, err := r.resource.Diff(context.Background(), r.state, config, r.meta)
+	if err != nil {
+		r.t.Fatalf("plan: %s", err)
+	}
+
+	return diff
+}
+
+func (r *testResource) apply(raw map[string]interface{}) *terraform.InstanceState {
+	r.t.Helper()
+
+	diff := r.plan(raw)
+	if diff == nil || diff.Empty() {
+		return r.state
+	}
+
+	state, diags := r.resource.Apply(context.Background(), r.state, diff, r.meta)
+	if diags.HasError() {
+		r.t.Fatalf("apply: %v", diags)
+	}
+	r.state = state
+
+	return state
+}
+
+// applyWithError applies a config that is expected to fail and keeps the
+// state the SDK saves for a partially created resource.
+func (r *testResource) applyWithError(raw map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	r.t.Helper()
+
+	state, diags := r.resource.Apply(context.Background(), r.state, r.plan(raw), r.meta)
+	if !diags.HasError() {
+		r.t.Fatal("expected apply to fail")
+	}
+	r.state = state
+
+	return state, diags
+}
+
+func (r *testResource) refresh() *terraform.InstanceState {
+	r.t.Helper()
+
+	state, diags := r.resource.RefreshWithoutUpgrade(context.Background(), r.state, r.meta)
+	if diags.HasError() {
+		r.t.Fatalf("refresh: %v", diags)
+	}
+	r.state = state
+
+	return state
+}
+
+func (r *testResource) importState(id string) *terraform.InstanceState {
+	r.t.Helper()
+
+	d := r.resource.Data(nil)
+	d.SetId(id)
+
+	imported, err := r.resource.Importer.StateContext(context.Background(), d, r.meta)
+	if err != nil {
+		r.t.Fatalf("import: %s", err)
+	}
+	r.state = imported[0].State()
+
+	return r.refresh()
+}
+
+func (r *testResource) readData(raw map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	r.t.Helper()
+
+	diff := r.plan(raw)
+
+	return r.resource.ReadDataApply(context.Background(), diff, r.meta)
+}
+
+func (r *testResource) destroy() {
+	r.t.Helper()
+
+	_, diags := r.resource.Apply(context.Background(), r.state, &terraform.InstanceDiff{Destroy: true}, r.meta)
+	if diags.HasError() {
+		r.t.Fatalf("destroy: %v", diags)
+	}
+	r.state = nil
+}
@@ -2,10 +2,12 @@ package provider
 
 import (
 	"context"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
@@ -15,6 +17,14 @@ func resourceApiPipelineEvent() *schema.Resource {
 		ReadContext:   resourceApiPipelineEventRead,
 		UpdateContext: resourceApiPipelineEventUpdate,
 		DeleteContext: resourceApiPipelineEventDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
+		CustomizeDiff: validatePipelineEventOnDestroy,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -22,19 +32,40 @@ func resourceApiPipelineEvent() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"pipeline_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"event_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineEventTypes, false)),
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"on_destroy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "NONE",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineEventOnDestroyActions, false)),
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -49,13 +80,19 @@ func resourceApiPipelineEvent() *schema.Resource {
 }
 
 func resourceApiPipelineEventCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	pipelineId := d.Get("pipeline_id").(string)
-	owningUserId := d.Get("owning_user_id").(string)
+	owningUserId, err := getOwningUserId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	eventType := d.Get("event_type").(string)
 
 	location, err := c.CreateApiPipelineEvent(&organizationId, &xc.PipelineEvent{
@@ -68,12 +105,19 @@ func resourceApiPipelineEventCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	time.Sleep(5 * time.Second)
 
 	id := getIdFromLocationUrl(location)
 
 	d.SetId(*id)
 
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
 	getPipelineStatusFunc := func() (*xc.PipelineStatus, error) {
 		pipeline, err := c.GetApiPipeline(&organizationId, &pipelineId)
 		if err != nil {
@@ -82,7 +126,7 @@ func resourceApiPipelineEventCreate(ctx context.Context, d *schema.ResourceData,
 		return pipeline.Status, nil
 	}
 
-	err = waitForPipelineEventToComplete(eventType, 30*time.Minute, 5*time.Second, getPipelineStatusFunc)
+	err = waitForPipelineEventToComplete(ctx, eventType, d.Timeout(schema.TimeoutCreate), getPipelineStatusFunc)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -104,7 +148,7 @@ func resourceApiPipelineEventCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceApiPipelineEventRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -113,6 +157,11 @@ func resourceApiPipelineEventRead(ctx context.Context, d *schema.ResourceData, m
 
 	apiPipelineEvent, err := c.GetApiPipelineEvent(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_api_pipeline_event (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -132,6 +181,12 @@ func resourceApiPipelineEventRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
+	if _, ok := d.GetOk("on_destroy"); !ok {
+		if err := d.Set("on_destroy", "NONE"); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if err := d.Set("owning_user_id", apiPipelineEvent.OwningUserId); err != nil {
 		return diag.FromErr(err)
 	}
@@ -152,7 +207,43 @@ func resourceApiPipelineEventUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceApiPipelineEventDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(xilutionClient)
+
 	var diags diag.Diagnostics
 
+	if d.Get("on_destroy").(string) != "DEPROVISION" {
+		return diags
+	}
+
+	organizationId := d.Get("organization_id").(string)
+	owningUserId := d.Get("owning_user_id").(string)
+	pipelineId := d.Get("pipeline_id").(string)
+
+	getPipelineStatusFunc := func() (*xc.PipelineStatus, error) {
+		pipeline, err := c.GetApiPipeline(&organizationId, &pipelineId)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline.Status, nil
+	}
+
+	status, err := getPipelineStatusFunc()
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] pipeline (%s) not found, nothing to deprovision", pipelineId)
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	if !isPipelineProvisioned(status) {
+		return diags
+	}
+
+	err = sendPipelineEvent(ctx, "DEPROVISION", organizationId, owningUserId, pipelineId, d.Timeout(schema.TimeoutDelete), c.CreateApiPipelineEvent, getPipelineStatusFunc)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccApiPipelineEventConfig(eventType string) string {
+	return testAccApiPipelineConfig("API", "master") + fmt.Sprintf(`
+resource "xilution_api_pipeline_event" "test" {
+  pipeline_id     = xilution_api_pipeline.test.id
+  event_type      = %q
+  organization_id = %q
+  owning_user_id  = %q
+}
+`, eventType, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionApiPipelineEvent_provision(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_api_pipeline_event.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_api_pipeline", "fox", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccApiPipelineEventConfig("PROVISION"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "pipeline_id", "xilution_api_pipeline.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "event_type", "PROVISION"),
+					testAccCheckPipelineInfrastructureStatus(api, "xilution_api_pipeline.test", CREATE_COMPLETE),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccApiPipelineEventTriggersConfig(configHash string) string {
+	return testAccApiPipelineConfig("API", "master") + fmt.Sprintf(`
+resource "xilution_api_pipeline_event" "test" {
+  pipeline_id     = xilution_api_pipeline.test.id
+  event_type      = "PROVISION"
+  organization_id = %q
+  owning_user_id  = %q
+
+  triggers = {
+    config_hash = %q
+  }
+}
+`, mockOrganizationId, mockUserId, configHash)
+}
+
+func TestAccXilutionApiPipelineEvent_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_api_pipeline_event.test"
+	pipelineResourceName := "xilution_api_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_api_pipeline", "fox", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccApiPipelineEventTriggersConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "pipeline_id", pipelineResourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "event_type", "PROVISION"),
+					resource.TestCheckResourceAttr(resourceName, "on_destroy", "NONE"),
+					resource.TestCheckResourceAttr(resourceName, "triggers.config_hash", "1"),
+					testAccCheckPipelineInfrastructureStatus(api, pipelineResourceName, CREATE_COMPLETE),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccApiPipelineEventTriggersConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "triggers.config_hash", "2"),
+					testAccCheckPipelineInfrastructureStatus(api, pipelineResourceName, CREATE_COMPLETE),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportStateIdFunc(resourceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy", "triggers"},
+			},
+			{
+				// Destroying the event with on_destroy = "NONE" leaves the
+				// pipeline's infrastructure running.
+				Config: testAccProviderConfig(api) + testAccApiPipelineConfig("API", "master"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPipelineInfrastructureStatus(api, pipelineResourceName, CREATE_COMPLETE),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testApiPipelineConfig(branch string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            "API 1",
+		"pipeline_type":   "AWS_SMALL",
+		"vpc_pipeline_id": "vpc-pipeline-1",
+		"git_repo_id":     "git-repo-1",
+		"branch":          branch,
+		"stages": []interface{}{
+			map[string]interface{}{"name": "test"},
+			map[string]interface{}{"name": "prod"},
+		},
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	}
+}
+
+func TestResourceApiPipeline_updatesMutableAttributes(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceApiPipeline(), testProviderMeta(c))
+
+	id := r.apply(testApiPipelineConfig("master")).ID
+
+	config := testApiPipelineConfig("main")
+	config["stages"] = []interface{}{
+		map[string]interface{}{"name": "prod"},
+	}
+	state := r.apply(config)
+
+	if state.ID != id {
+		t.Fatalf("expected an in-place update, id changed from %s to %s", id, state.ID)
+	}
+	if pipeline := c.apiPipelines[id]; pipeline.Branch != "main" || len(pipeline.Stages) != 1 {
+		t.Fatalf("expected branch and stages to be updated, got %+v", pipeline)
+	}
+
+	config["vpc_pipeline_id"] = "vpc-pipeline-2"
+	if diff := r.plan(config); !diff.RequiresNew() {
+		t.Fatal("expected changing vpc_pipeline_id to require a new pipeline")
+	}
+}
+
+func TestResourceApiPipeline_destroyRemovedOutsideTerraform(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceApiPipeline(), testProviderMeta(c))
+
+	config := testApiPipelineConfig("master")
+	config["provisioned"] = true
+	state := r.apply(config)
+	delete(c.apiPipelines, state.ID)
+
+	r.destroy()
+
+	if len(c.sentDeprovisionEvents()) != 0 {
+		t.Fatal("expected nothing to be deprovisioned")
+	}
+}
+
+func TestResourceApiPipeline_reprovisionsOnInfrastructureChanges(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceApiPipeline(), testProviderMeta(c))
+
+	config := testApiPipelineConfig("master")
+	config["provisioned"] = true
+	id := r.apply(config).ID
+
+	config["name"] = "API 2"
+	r.apply(config)
+
+	config["branch"] = "main"
+	state := r.apply(config)
+	if state.Attributes["infrastructure_status"] != UPDATE_COMPLETE {
+		t.Fatalf("expected the pipeline to be reprovisioned, got %v", state.Attributes)
+	}
+
+	expected := []string{"PROVISION", "REPROVISION"}
+	if actual := c.sentPipelineEvents(id); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected events %v, got %v", expected, actual)
+	}
+}
+
+func testAccApiPipelineConfig(name string, branch string) string {
+	return testAccVpcPipelineConfig("VPC") + testAccGitRepoConfig("api") + fmt.Sprintf(`
+resource "xilution_api_pipeline" "test" {
+  name            = %q
+  pipeline_type   = "AWS_SMALL"
+  vpc_pipeline_id = xilution_vpc_pipeline.test.id
+  git_repo_id     = xilution_git_repo.test.id
+  branch          = %q
+  organization_id = %q
+  owning_user_id  = %q
+
+  stages {
+    name = "test"
+  }
+
+  stages {
+    name = "prod"
+  }
+}
+`, name, branch, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionApiPipeline_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_api_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_api_pipeline", "fox", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccApiPipelineConfig("API 1", "master"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "API 1"),
+					resource.TestCheckResourceAttr(resourceName, "branch", "master"),
+					resource.TestCheckResourceAttr(resourceName, "stages.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "stages.0.name", "test"),
+					resource.TestCheckResourceAttrPair(resourceName, "git_repo_id", "xilution_git_repo.test", "id"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccApiPipelineConfig("API 2", "main"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "API 2"),
+					resource.TestCheckResourceAttr(resourceName, "branch", "main"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
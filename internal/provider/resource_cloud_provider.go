@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
@@ -14,6 +16,9 @@ func resourceCloudProvider() *schema.Resource {
 		ReadContext:   resourceCloudProviderRead,
 		UpdateContext: resourceCloudProviderUpdate,
 		DeleteContext: resourceCloudProviderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -24,24 +29,33 @@ func resourceCloudProvider() *schema.Resource {
 				Required: true,
 			},
 			"cloud_provider": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(cloudProviders, false)),
 			},
 			"account_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateAwsAccountId,
 			},
 			"region": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateAwsRegion,
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -56,16 +70,22 @@ func resourceCloudProvider() *schema.Resource {
 }
 
 func resourceCloudProviderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	provider := d.Get("cloud_provider").(string)
 	accountId := d.Get("account_id").(string)
 	region := d.Get("region").(string)
-	owningUserId := d.Get("owning_user_id").(string)
+	owningUserId, err := getOwningUserId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	location, err := c.CreateCloudProvider(&organizationId, &xc.CloudProvider{
 		Type:           "cloud-provider",
@@ -84,6 +104,14 @@ func resourceCloudProviderCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(*id)
 
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
 	cloudProvider, err := c.GetCloudProvider(&organizationId, id)
 	if err != nil {
 		return diag.FromErr(err)
@@ -101,7 +129,7 @@ func resourceCloudProviderCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudProviderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -110,6 +138,11 @@ func resourceCloudProviderRead(ctx context.Context, d *schema.ResourceData, m in
 
 	cloudProvider, err := c.GetCloudProvider(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_cloud_provider (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -153,7 +186,7 @@ func resourceCloudProviderRead(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceCloudProviderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	id := d.Id()
 	name := d.Get("name").(string)
@@ -163,7 +196,7 @@ func resourceCloudProviderUpdate(ctx context.Context, d *schema.ResourceData, m
 	region := d.Get("region").(string)
 	owningUserId := d.Get("owning_user_id").(string)
 
-	if d.HasChange("name") {
+	if d.HasChanges("name", "owning_user_id") {
 		err := c.UpdateCloudProvider(&organizationId, &xc.CloudProvider{
 			Type:           "cloud-provider",
 			ID:             id,
@@ -183,7 +216,7 @@ func resourceCloudProviderUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceCloudProviderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccCloudProviderConfig(name string) string {
+	return fmt.Sprintf(`
+resource "xilution_cloud_provider" "test" {
+  name            = %q
+  cloud_provider  = "AWS"
+  account_id      = "123456789012"
+  region          = "us-east-1"
+  organization_id = %q
+  owning_user_id  = %q
+}
+`, name, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionCloudProvider_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_cloud_provider.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_cloud_provider", "kangaroo", "cloud-providers"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccCloudProviderConfig("AWS"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "AWS"),
+					resource.TestCheckResourceAttr(resourceName, "account_id", "123456789012"),
+					resource.TestCheckResourceAttr(resourceName, "region", "us-east-1"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccCloudProviderConfig("AWS Production"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "AWS Production"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
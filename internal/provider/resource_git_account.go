@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
@@ -14,6 +16,9 @@ func resourceGitAccount() *schema.Resource {
 		ReadContext:   resourceGitAccountRead,
 		UpdateContext: resourceGitAccountUpdate,
 		DeleteContext: resourceGitAccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -24,16 +29,21 @@ func resourceGitAccount() *schema.Resource {
 				Required: true,
 			},
 			"git_provider": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(gitProviders, false)),
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -48,14 +58,20 @@ func resourceGitAccount() *schema.Resource {
 }
 
 func resourceGitAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
 	provider := d.Get("git_provider").(string)
-	organizationId := d.Get("organization_id").(string)
-	owningUserId := d.Get("owning_user_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	owningUserId, err := getOwningUserId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	location, err := c.CreateGitAccount(&organizationId, &xc.GitAccount{
 		Type:           "git-account",
@@ -72,6 +88,14 @@ func resourceGitAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	d.SetId(*id)
 
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
 	gitAccount, err := c.GetGitAccount(&organizationId, id)
 	if err != nil {
 		return diag.FromErr(err)
@@ -89,7 +113,7 @@ func resourceGitAccountCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceGitAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -98,6 +122,11 @@ func resourceGitAccountRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	gitAccount, err := c.GetGitAccount(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_git_account (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -133,7 +162,7 @@ func resourceGitAccountRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceGitAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	id := d.Id()
 	name := d.Get("name").(string)
@@ -141,7 +170,7 @@ func resourceGitAccountUpdate(ctx context.Context, d *schema.ResourceData, m int
 	organizationId := d.Get("organization_id").(string)
 	owningUserId := d.Get("owning_user_id").(string)
 
-	if d.HasChange("name") {
+	if d.HasChanges("name", "owning_user_id") {
 		err := c.UpdateGitAccount(&organizationId, &xc.GitAccount{
 			Type:           "git-account",
 			ID:             id,
@@ -159,7 +188,7 @@ func resourceGitAccountUpdate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceGitAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccGitAccountConfig(name string) string {
+	return fmt.Sprintf(`
+resource "xilution_git_account" "test" {
+  name            = %q
+  git_provider    = "GIT_HUB"
+  organization_id = %q
+  owning_user_id  = %q
+}
+`, name, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionGitAccount_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_git_account", "swan", "git-accounts"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitAccountConfig("xilution"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "xilution"),
+					resource.TestCheckResourceAttr(resourceName, "git_provider", "GIT_HUB"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccGitAccountConfig("xilution-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "xilution-renamed"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccXilutionGitAccount_providerDefaults(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_account.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_git_account", "swan", "git-accounts"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + `
+resource "xilution_git_account" "test" {
+  name         = "xilution"
+  git_provider = "GIT_HUB"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "organization_id", mockOrganizationId),
+					resource.TestCheckResourceAttr(resourceName, "owning_user_id", mockUserId),
+				),
+			},
+		},
+	})
+}
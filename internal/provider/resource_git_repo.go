@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -14,6 +15,9 @@ func resourceGitRepo() *schema.Resource {
 		ReadContext:   resourceGitRepoRead,
 		UpdateContext: resourceGitRepoUpdate,
 		DeleteContext: resourceGitRepoDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -26,14 +30,18 @@ func resourceGitRepo() *schema.Resource {
 			"git_account_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -48,14 +56,20 @@ func resourceGitRepo() *schema.Resource {
 }
 
 func resourceGitRepoCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	gitAccountId := d.Get("git_account_id").(string)
-	owningUserId := d.Get("owning_user_id").(string)
+	owningUserId, err := getOwningUserId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	location, err := c.CreateGitRepo(&organizationId, &xc.GitRepo{
 		Type:           "git-repo",
@@ -72,6 +86,14 @@ func resourceGitRepoCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.SetId(*id)
 
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
 	gitRepo, err := c.GetGitRepo(&organizationId, id)
 	if err != nil {
 		return diag.FromErr(err)
@@ -89,7 +111,7 @@ func resourceGitRepoCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceGitRepoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -98,6 +120,11 @@ func resourceGitRepoRead(ctx context.Context, d *schema.ResourceData, m interfac
 
 	gitRepo, err := c.GetGitRepo(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_git_repo (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -133,7 +160,7 @@ func resourceGitRepoRead(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceGitRepoUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	id := d.Id()
 	name := d.Get("name").(string)
@@ -141,7 +168,7 @@ func resourceGitRepoUpdate(ctx context.Context, d *schema.ResourceData, m interf
 	gitAccountId := d.Get("git_account_id").(string)
 	owningUserId := d.Get("owning_user_id").(string)
 
-	if d.HasChange("name") {
+	if d.HasChanges("name", "owning_user_id") {
 		err := c.UpdateGitRepo(&organizationId, &xc.GitRepo{
 			Type:           "git-repo",
 			ID:             id,
@@ -159,7 +186,7 @@ func resourceGitRepoUpdate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceGitRepoDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
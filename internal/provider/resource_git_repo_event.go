@@ -8,15 +8,27 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
+// resourceGitRepoEvent runs a one-off action against a git repo. Every input
+// forces replacement, since an event can't be edited: changing any of them,
+// even create_repo_from_template's description or commit_message, sends a new
+// event that runs CREATE_REPO_FROM_TEMPLATE_REPO again against the existing
+// repo.
 func resourceGitRepoEvent() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceGitRepoEventCreate,
 		ReadContext:   resourceGitRepoEventRead,
-		UpdateContext: resourceGitRepoEventUpdate,
 		DeleteContext: resourceGitRepoEventDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -25,26 +37,49 @@ func resourceGitRepoEvent() *schema.Resource {
 			"git_account_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"git_repo_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"event_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(gitRepoEventTypes, false)),
+			},
+			"create_repo_from_template": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				Elem:         createRepoFromTemplateResource(),
+				ExactlyOneOf: []string{"create_repo_from_template", "parameters"},
 			},
 			"parameters": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+				StateFunc:        normalizeJson,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				ExactlyOneOf:     []string{"create_repo_from_template", "parameters"},
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -59,22 +94,25 @@ func resourceGitRepoEvent() *schema.Resource {
 }
 
 func resourceGitRepoEventCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
-	organizationId := d.Get("organization_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	gitAccountId := d.Get("git_account_id").(string)
 	gitRepoId := d.Get("git_repo_id").(string)
-	owningUserId := d.Get("owning_user_id").(string)
-	parametersStr := d.Get("parameters").(string)
-	var parametersData map[string]interface{}
-	err := json.Unmarshal([]byte(parametersStr), &parametersData)
+	owningUserId, err := getOwningUserId(d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-
 	eventType := d.Get("event_type").(string)
+	parametersData, err := expandGitRepoEventParameters(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	location, err := c.CreateGitRepoEvent(&organizationId, &xc.GitRepoEvent{
 		Type:           "git-repo-event",
@@ -88,31 +126,30 @@ func resourceGitRepoEventCreate(ctx context.Context, d *schema.ResourceData, m i
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	time.Sleep(5 * time.Second)
 
 	id := getIdFromLocationUrl(location)
 
 	d.SetId(*id)
 
-	timeoutInMinutes := 15.0
-	done := false
-	start := time.Now()
-	for !done {
-		log.Println("[DEBUG] Git Repo id is ", &gitRepoId)
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	getGitRepoStatusFunc := func() (string, error) {
 		gitRepo, err := c.GetGitRepo(&organizationId, &gitRepoId)
 		if err != nil {
-			return diag.FromErr(err)
-		}
-		status := gitRepo.Status
-		log.Println("[DEBUG] Git Repo Status is ", status)
-		if status == "ACTIVE" {
-			done = true
-		} else {
-			if time.Since(start).Minutes() > timeoutInMinutes {
-				return diag.FromErr(err)
-			}
-			time.Sleep(5 * time.Second)
+			return "", err
 		}
+		return gitRepo.Status, nil
+	}
+
+	err = waitForGitRepoToBeActive(ctx, d.Timeout(schema.TimeoutCreate), getGitRepoStatusFunc)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	gitRepoEvent, err := c.GetGitRepoEvent(&organizationId, id)
@@ -132,7 +169,7 @@ func resourceGitRepoEventCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceGitRepoEventRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -141,6 +178,11 @@ func resourceGitRepoEventRead(ctx context.Context, d *schema.ResourceData, m int
 
 	gitRepoEvent, err := c.GetGitRepoEvent(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_git_repo_event (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -152,6 +194,10 @@ func resourceGitRepoEventRead(ctx context.Context, d *schema.ResourceData, m int
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("git_repo_id", gitRepoEvent.GitRepoId); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("organization_id", gitRepoEvent.OrganizationId); err != nil {
 		return diag.FromErr(err)
 	}
@@ -160,11 +206,18 @@ func resourceGitRepoEventRead(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	log.Println("[DEBUG] jsonStr: " + string(jsonStr))
 	if err := d.Set("parameters", string(jsonStr)); err != nil {
 		return diag.FromErr(err)
 	}
 
+	createRepoFromTemplate, err := flattenCreateRepoFromTemplate(gitRepoEvent)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("create_repo_from_template", createRepoFromTemplate); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("event_type", gitRepoEvent.EventType); err != nil {
 		return diag.FromErr(err)
 	}
@@ -184,12 +237,142 @@ func resourceGitRepoEventRead(ctx context.Context, d *schema.ResourceData, m int
 	return diags
 }
 
-func resourceGitRepoEventUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	return resourceGitRepoEventRead(ctx, d, m)
-}
-
 func resourceGitRepoEventDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	return diags
 }
+
+func createRepoFromTemplateResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"source_owner": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"source_repo": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"commit_message": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"is_private": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"template_params": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// expandGitRepoEventParameters builds the parameters of a git repo event from
+// its typed event block, or from the JSON parameters attribute when no block
+// is set.
+func expandGitRepoEventParameters(d *schema.ResourceData) (map[string]interface{}, error) {
+	if l := d.Get("create_repo_from_template").([]interface{}); len(l) > 0 && l[0] != nil {
+		createRepoFromTemplate := l[0].(map[string]interface{})
+
+		parameters := map[string]interface{}{
+			"sourceOwner": createRepoFromTemplate["source_owner"].(string),
+			"sourceRepo":  createRepoFromTemplate["source_repo"].(string),
+			"isPrivate":   createRepoFromTemplate["is_private"].(bool),
+		}
+
+		if description := createRepoFromTemplate["description"].(string); description != "" {
+			parameters["description"] = description
+		}
+
+		if commitMessage := createRepoFromTemplate["commit_message"].(string); commitMessage != "" {
+			parameters["commitMessage"] = commitMessage
+		}
+
+		// The API takes the template parameters as a JSON encoded string.
+		if templateParams := createRepoFromTemplate["template_params"].(map[string]interface{}); len(templateParams) > 0 {
+			params, err := json.Marshal(templateParams)
+			if err != nil {
+				return nil, err
+			}
+			parameters["params"] = string(params)
+		}
+
+		return parameters, nil
+	}
+
+	var parameters map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("parameters").(string)), &parameters); err != nil {
+		return nil, err
+	}
+
+	return parameters, nil
+}
+
+// flattenCreateRepoFromTemplate returns the create_repo_from_template block of
+// a CREATE_REPO_FROM_TEMPLATE_REPO event, and no block for other events.
+func flattenCreateRepoFromTemplate(gitRepoEvent *xc.GitRepoEvent) ([]interface{}, error) {
+	if gitRepoEvent.EventType != "CREATE_REPO_FROM_TEMPLATE_REPO" {
+		return []interface{}{}, nil
+	}
+
+	parameters := gitRepoEvent.Parameters
+
+	createRepoFromTemplate := map[string]interface{}{}
+	if sourceOwner, ok := parameters["sourceOwner"].(string); ok {
+		createRepoFromTemplate["source_owner"] = sourceOwner
+	}
+	if sourceRepo, ok := parameters["sourceRepo"].(string); ok {
+		createRepoFromTemplate["source_repo"] = sourceRepo
+	}
+	if description, ok := parameters["description"].(string); ok {
+		createRepoFromTemplate["description"] = description
+	}
+	if commitMessage, ok := parameters["commitMessage"].(string); ok {
+		createRepoFromTemplate["commit_message"] = commitMessage
+	}
+	if isPrivate, ok := parameters["isPrivate"].(bool); ok {
+		createRepoFromTemplate["is_private"] = isPrivate
+	}
+
+	if params, ok := parameters["params"].(string); ok && params != "" {
+		var templateParams map[string]interface{}
+		if err := json.Unmarshal([]byte(params), &templateParams); err != nil {
+			return nil, err
+		}
+
+		flattened := map[string]interface{}{}
+		for key, value := range templateParams {
+			if s, ok := value.(string); ok {
+				flattened[key] = s
+				continue
+			}
+
+			data, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			flattened[key] = string(data)
+		}
+		createRepoFromTemplate["template_params"] = flattened
+	}
+
+	return []interface{}{createRepoFromTemplate}, nil
+}
@@ -0,0 +1,267 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceGitRepoEvent_waitsForRepo(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	gitRepo := newTestResource(t, resourceGitRepo(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "xilution-temp",
+		"git_account_id":  "git-account-1",
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	})
+
+	state := newTestResource(t, resourceGitRepoEvent(), testProviderMeta(c)).apply(map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"git_account_id":  "git-account-1",
+		"git_repo_id":     gitRepo.ID,
+		"event_type":      "CREATE_REPO_FROM_TEMPLATE_REPO",
+		"parameters":      `{"sourceOwner":"xilution","sourceRepo":"xilution-bison-poc-template"}`,
+	})
+
+	if state.Attributes["git_repo_id"] != gitRepo.ID {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+	if c.gitRepos[gitRepo.ID].Status != ACTIVE {
+		t.Fatal("expected the git repo to be active")
+	}
+}
+
+func TestResourceGitRepoEvent_createRepoFromTemplate(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	gitRepo := newTestResource(t, resourceGitRepo(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "xilution-temp",
+		"git_account_id":  "git-account-1",
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	})
+
+	config := map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"git_account_id":  "git-account-1",
+		"git_repo_id":     gitRepo.ID,
+		"event_type":      "CREATE_REPO_FROM_TEMPLATE_REPO",
+		"create_repo_from_template": []interface{}{
+			map[string]interface{}{
+				"source_owner":   "xilution",
+				"source_repo":    "xilution-bison-poc-template",
+				"commit_message": "Initial repo setup",
+				"is_private":     true,
+				"template_params": map[string]interface{}{
+					"world": "planet",
+				},
+			},
+		},
+	}
+
+	r := newTestResource(t, resourceGitRepoEvent(), testProviderMeta(c))
+	state := r.apply(config)
+
+	expected := map[string]interface{}{
+		"sourceOwner":   "xilution",
+		"sourceRepo":    "xilution-bison-poc-template",
+		"commitMessage": "Initial repo setup",
+		"isPrivate":     true,
+		"params":        `{"world":"planet"}`,
+	}
+	if actual := c.gitRepoEvents[state.ID].Parameters; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("unexpected parameters: %v", actual)
+	}
+
+	state = r.refresh()
+	if state.Attributes["create_repo_from_template.0.template_params.world"] != "planet" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+
+	if diff := r.plan(config); diff != nil && !diff.Empty() {
+		t.Fatalf("expected no changes, got %v", diff)
+	}
+}
+
+func TestResourceGitRepoEvent_normalizesParameters(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	gitRepo := newTestResource(t, resourceGitRepo(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "xilution-temp",
+		"git_account_id":  "git-account-1",
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	})
+
+	config := map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"git_account_id":  "git-account-1",
+		"git_repo_id":     gitRepo.ID,
+		"event_type":      "CREATE_REPO_FROM_TEMPLATE_REPO",
+		"parameters": `{
+  "sourceRepo":  "xilution-bison-poc-template",
+  "sourceOwner": "xilution"
+}`,
+	}
+
+	r := newTestResource(t, resourceGitRepoEvent(), testProviderMeta(c))
+	r.apply(config)
+
+	state := r.refresh()
+	if state.Attributes["parameters"] != `{"sourceOwner":"xilution","sourceRepo":"xilution-bison-poc-template"}` {
+		t.Fatalf("unexpected parameters: %s", state.Attributes["parameters"])
+	}
+
+	if diff := r.plan(config); diff != nil && !diff.Empty() {
+		t.Fatalf("expected no changes, got %v", diff)
+	}
+}
+
+func TestResourceGitRepoEvent_changesRequireReplacement(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	gitRepo := newTestResource(t, resourceGitRepo(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "xilution-temp",
+		"git_account_id":  "git-account-1",
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	})
+
+	config := func(createRepoFromTemplate map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"organization_id":           "org-1",
+			"owning_user_id":            "user-1",
+			"git_account_id":            "git-account-1",
+			"git_repo_id":               gitRepo.ID,
+			"event_type":                "CREATE_REPO_FROM_TEMPLATE_REPO",
+			"create_repo_from_template": []interface{}{createRepoFromTemplate},
+		}
+	}
+
+	r := newTestResource(t, resourceGitRepoEvent(), testProviderMeta(c))
+	r.apply(config(map[string]interface{}{
+		"source_owner":    "xilution",
+		"source_repo":     "xilution-bison-poc-template",
+		"template_params": map[string]interface{}{"world": "planet"},
+	}))
+
+	for name, changed := range map[string]map[string]interface{}{
+		"source_repo": config(map[string]interface{}{
+			"source_owner":    "xilution",
+			"source_repo":     "xilution-gazelle-poc-template",
+			"template_params": map[string]interface{}{"world": "planet"},
+		}),
+		"template_params": config(map[string]interface{}{
+			"source_owner":    "xilution",
+			"source_repo":     "xilution-bison-poc-template",
+			"template_params": map[string]interface{}{"world": "moon"},
+		}),
+		"description": config(map[string]interface{}{
+			"source_owner":    "xilution",
+			"source_repo":     "xilution-bison-poc-template",
+			"description":     "A new repo from a copy",
+			"template_params": map[string]interface{}{"world": "planet"},
+		}),
+		"commit_message": config(map[string]interface{}{
+			"source_owner":    "xilution",
+			"source_repo":     "xilution-bison-poc-template",
+			"commit_message":  "Initial repo setup",
+			"template_params": map[string]interface{}{"world": "planet"},
+		}),
+	} {
+		if diff := r.plan(changed); diff == nil || !diff.RequiresNew() {
+			t.Errorf("%s: expected the change to replace the event, got %v", name, diff)
+		}
+	}
+
+	parametersConfig := config(nil)
+	delete(parametersConfig, "create_repo_from_template")
+	parametersConfig["parameters"] = `{"sourceOwner":"xilution","sourceRepo":"xilution-gazelle-poc-template"}`
+	if diff := r.plan(parametersConfig); diff == nil || !diff.RequiresNew() {
+		t.Errorf("parameters: expected the change to replace the event, got %v", diff)
+	}
+}
+
+func TestResourceGitRepoEvent_invalidParameters(t *testing.T) {
+	diags := resourceGitRepoEvent().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"git_account_id": "git-account-1",
+		"git_repo_id":    "git-repo-1",
+		"event_type":     "CREATE_REPO_FROM_TEMPLATE_REPO",
+		"parameters":     "sourceOwner=xilution",
+	}))
+	if !diags.HasError() {
+		t.Fatal("expected parameters that are not JSON to be rejected")
+	}
+}
+
+func testAccGitRepoEventConfig() string {
+	return testAccGitRepoConfig("website") + fmt.Sprintf(`
+resource "xilution_git_repo_event" "test" {
+  git_account_id  = xilution_git_account.test.id
+  git_repo_id     = xilution_git_repo.test.id
+  event_type      = "CREATE_REPO_FROM_TEMPLATE_REPO"
+  organization_id = %q
+  owning_user_id  = %q
+
+  create_repo_from_template {
+    source_owner = "xilution"
+    source_repo  = "xilution-bison-poc-template"
+  }
+}
+`, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionGitRepoEvent_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_repo_event.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_git_repo", "swan", "git-repos"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitRepoEventConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "git_repo_id", "xilution_git_repo.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "event_type", "CREATE_REPO_FROM_TEMPLATE_REPO"),
+					testAccCheckGitRepoActive(api, "xilution_git_repo.test"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckGitRepoActive(api *mockXilutionApi, resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		if status := api.status("swan", "git-repos", rs.Primary.ID); status != ACTIVE {
+			return fmt.Errorf("%s status is %q, expected %s", resourceName, status, ACTIVE)
+		}
+
+		return nil
+	}
+}
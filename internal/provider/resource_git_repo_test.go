@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccGitRepoConfig(name string) string {
+	return testAccGitAccountConfig("xilution") + fmt.Sprintf(`
+resource "xilution_git_repo" "test" {
+  name            = %q
+  git_account_id  = xilution_git_account.test.id
+  organization_id = %q
+  owning_user_id  = %q
+}
+`, name, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionGitRepo_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_git_repo.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_git_repo", "swan", "git-repos"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccGitRepoConfig("website"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "website"),
+					resource.TestCheckResourceAttrPair(resourceName, "git_account_id", "xilution_git_account.test", "id"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccGitRepoConfig("website-renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "website-renamed"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
@@ -15,6 +18,15 @@ func resourceK8sPipeline() *schema.Resource {
 		ReadContext:   resourceK8sPipelineRead,
 		UpdateContext: resourceK8sPipelineUpdate,
 		DeleteContext: resourceK8sPipelineDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			// Delete leaves time for force_destroy to deprovision dependents.
+			Delete: schema.DefaultTimeout(75 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -25,20 +37,38 @@ func resourceK8sPipeline() *schema.Resource {
 				Required: true,
 			},
 			"pipeline_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineTypes, false)),
 			},
 			"vpc_pipeline_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+			},
+			"provisioned": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"retain_infrastructure_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -48,20 +78,38 @@ func resourceK8sPipeline() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"infrastructure_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_up_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_down_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func resourceK8sPipelineCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
 	pipelineType := d.Get("pipeline_type").(string)
 	vpcPipelineId := d.Get("vpc_pipeline_id").(string)
-	organizationId := d.Get("organization_id").(string)
-	owningUserId := d.Get("owning_user_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	owningUserId, err := getOwningUserId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	location, err := c.CreateK8sPipeline(&organizationId, &xc.K8sPipeline{
 		Type:           "pipeline",
@@ -79,6 +127,29 @@ func resourceK8sPipelineCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	d.SetId(*id)
 
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("provisioned").(bool) {
+		getPipelineStatusFunc := func() (*xc.PipelineStatus, error) {
+			pipeline, err := c.GetK8sPipeline(&organizationId, id)
+			if err != nil {
+				return nil, err
+			}
+			return pipeline.Status, nil
+		}
+
+		err = sendPipelineEvent(ctx, "PROVISION", organizationId, owningUserId, *id, d.Timeout(schema.TimeoutCreate), c.CreateK8sPipelineEvent, getPipelineStatusFunc)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	k8sPipeline, err := c.GetK8sPipeline(&organizationId, id)
 	if err != nil {
 		return diag.FromErr(err)
@@ -92,11 +163,15 @@ func resourceK8sPipelineCreate(ctx context.Context, d *schema.ResourceData, m in
 		return diag.FromErr(err)
 	}
 
+	if err := setPipelineStatus(d, k8sPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
 func resourceK8sPipelineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -105,6 +180,11 @@ func resourceK8sPipelineRead(ctx context.Context, d *schema.ResourceData, m inte
 
 	k8sPipeline, err := c.GetK8sPipeline(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_k8s_pipeline (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -140,11 +220,19 @@ func resourceK8sPipelineRead(ctx context.Context, d *schema.ResourceData, m inte
 		return diag.FromErr(err)
 	}
 
+	if err := setPipelineStatus(d, k8sPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setPipelineProvisioned(d, k8sPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
 func resourceK8sPipelineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	id := d.Id()
 	name := d.Get("name").(string)
@@ -153,7 +241,7 @@ func resourceK8sPipelineUpdate(ctx context.Context, d *schema.ResourceData, m in
 	organizationId := d.Get("organization_id").(string)
 	owningUserId := d.Get("owning_user_id").(string)
 
-	if d.HasChange("name") {
+	if d.HasChanges("name", "owning_user_id") {
 		err := c.UpdateK8sPipeline(&organizationId, &xc.K8sPipeline{
 			Type:           "pipeline",
 			ID:             id,
@@ -168,11 +256,31 @@ func resourceK8sPipelineUpdate(ctx context.Context, d *schema.ResourceData, m in
 		}
 	}
 
+	getPipelineStatusFunc := func() (*xc.PipelineStatus, error) {
+		pipeline, err := c.GetK8sPipeline(&organizationId, &id)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline.Status, nil
+	}
+
+	status, err := getPipelineStatusFunc()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if eventType := pipelineProvisioningEventType(d, status); eventType != "" {
+		err = sendPipelineEvent(ctx, eventType, organizationId, owningUserId, id, d.Timeout(schema.TimeoutUpdate), c.CreateK8sPipelineEvent, getPipelineStatusFunc)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceK8sPipelineRead(ctx, d, m)
 }
 
 func resourceK8sPipelineDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -190,10 +298,33 @@ func resourceK8sPipelineDelete(ctx context.Context, d *schema.ResourceData, m in
 
 	status, err := getPipelineStatusFunc()
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_k8s_pipeline (%s) not found, nothing to delete", id)
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
-	if status.InfrastructureStatus != NOT_FOUND {
+	if isPipelineProvisioned(status) && !d.Get("retain_infrastructure_on_destroy").(bool) {
+		dependents, err := k8sPipelineDependents(c, organizationId, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		diags = append(diags, deprovisionDependentPipelines(ctx, d, "k8s pipeline", organizationId, dependents)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	if isPipelineProvisioned(status) && d.Get("retain_infrastructure_on_destroy").(bool) {
+		log.Printf("[WARN] Retaining the infrastructure of pipeline %s", id)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The cloud infrastructure of pipeline %s was left running", id),
+			Detail:   retainedInfrastructureDetail,
+		})
+	} else if isPipelineProvisioned(status) {
 		_, err = c.CreateK8sPipelineEvent(&organizationId, &xc.PipelineEvent{
 			Type:           "pipeline-event",
 			PipelineId:     id,
@@ -204,9 +335,8 @@ func resourceK8sPipelineDelete(ctx context.Context, d *schema.ResourceData, m in
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		time.Sleep(5 * time.Second)
 
-		err = waitForPipelineInfrastructureNotFound(45*time.Minute, 5*time.Second, getPipelineStatusFunc)
+		err = waitForPipelineInfrastructureNotFound(ctx, remainingTimeout(ctx, d.Timeout(schema.TimeoutDelete)), getPipelineStatusFunc)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -221,3 +351,39 @@ func resourceK8sPipelineDelete(ctx context.Context, d *schema.ResourceData, m in
 
 	return diags
 }
+
+// k8sPipelineDependents returns the word press pipelines that run in a k8s
+// pipeline.
+func k8sPipelineDependents(c xilutionClient, organizationId string, id string) ([]dependentPipeline, error) {
+	filter := &listFilter{
+		values: map[string]string{"k8s_pipeline_id": id},
+	}
+
+	dependents := []dependentPipeline{}
+
+	wordPressPipelines, err := listWordPressPipelines(c, organizationId, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, wordPressPipeline := range wordPressPipelines {
+		wordPressPipelineId := wordPressPipeline.ID
+
+		dependents = append(dependents, dependentPipeline{
+			resourceType:            "xilution_word_press_pipeline",
+			id:                      wordPressPipelineId,
+			name:                    wordPressPipeline.Name,
+			owningUserId:            wordPressPipeline.OwningUserId,
+			createPipelineEventFunc: c.CreateWordPressPipelineEvent,
+			getPipelineStatusFunc: func() (*xc.PipelineStatus, error) {
+				pipeline, err := c.GetWordPressPipeline(&organizationId, &wordPressPipelineId)
+				if err != nil {
+					return nil, err
+				}
+				return pipeline.Status, nil
+			},
+		})
+	}
+
+	return dependents, nil
+}
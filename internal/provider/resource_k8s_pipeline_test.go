@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccK8sPipelineConfig(name string) string {
+	return testAccVpcPipelineConfig("VPC") + fmt.Sprintf(`
+resource "xilution_k8s_pipeline" "test" {
+  name            = %q
+  pipeline_type   = "AWS_SMALL"
+  vpc_pipeline_id = xilution_vpc_pipeline.test.id
+  organization_id = %q
+  owning_user_id  = %q
+}
+`, name, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionK8sPipeline_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_k8s_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_k8s_pipeline", "giraffe", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccK8sPipelineConfig("K8s 1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "K8s 1"),
+					resource.TestCheckResourceAttr(resourceName, "pipeline_type", "AWS_SMALL"),
+					resource.TestCheckResourceAttrPair(resourceName, "vpc_pipeline_id", "xilution_vpc_pipeline.test", "id"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccK8sPipelineConfig("K8s 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "K8s 2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
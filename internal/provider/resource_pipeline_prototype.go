@@ -3,9 +3,13 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
@@ -15,6 +19,81 @@ func resourcePipelinePrototype() *schema.Resource {
 		ReadContext:   resourcePipelinePrototypeRead,
 		UpdateContext: resourcePipelinePrototypeUpdate,
 		DeleteContext: resourcePipelinePrototypeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourcePipelinePrototypeV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourcePipelinePrototypeStateUpgradeV0,
+				Version: 0,
+			},
+		},
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"active": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+			"reference": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     pipelinePrototypeReferenceResource(),
+			},
+			"parameter_definition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     pipelinePrototypeParameterDefinitionResource(),
+			},
+			"terraform": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem:     pipelinePrototypeTerraformResource(),
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"owning_user_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"modified_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourcePipelinePrototypeV0 is the schema of pipeline prototypes whose
+// references, parameter definitions and terraform settings were JSON strings.
+func resourcePipelinePrototypeV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -50,11 +129,14 @@ func resourcePipelinePrototype() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -68,8 +150,54 @@ func resourcePipelinePrototype() *schema.Resource {
 	}
 }
 
+// resourcePipelinePrototypeStateUpgradeV0 decodes the JSON strings of a v0
+// pipeline prototype into the reference, parameter_definition and terraform
+// blocks.
+func resourcePipelinePrototypeStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	references := []xc.Reference{}
+	if err := unmarshalStateString(rawState, "references", &references); err != nil {
+		return nil, err
+	}
+	delete(rawState, "references")
+	rawState["reference"] = flattenPipelinePrototypeReferences(references)
+
+	parameterDefinitions := []xc.ParameterDefinition{}
+	if err := unmarshalStateString(rawState, "parameter_definitions", &parameterDefinitions); err != nil {
+		return nil, err
+	}
+	flattenedParameterDefinitions, err := flattenPipelinePrototypeParameterDefinitions(parameterDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	delete(rawState, "parameter_definitions")
+	rawState["parameter_definition"] = flattenedParameterDefinitions
+
+	terraform := xc.Terraform{}
+	if err := unmarshalStateString(rawState, "terraform", &terraform); err != nil {
+		return nil, err
+	}
+	rawState["terraform"] = flattenPipelinePrototypeTerraform(terraform)
+
+	return rawState, nil
+}
+
+// unmarshalStateString decodes a JSON string attribute of a raw state into v.
+// A missing or empty attribute leaves v as is.
+func unmarshalStateString(rawState map[string]interface{}, key string, v interface{}) error {
+	s, _ := rawState[key].(string)
+	if s == "" {
+		return nil
+	}
+
+	if err := json.Unmarshal([]byte(s), v); err != nil {
+		return fmt.Errorf("unable to decode %s: %w", key, err)
+	}
+
+	return nil
+}
+
 func resourcePipelinePrototypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -77,23 +205,15 @@ func resourcePipelinePrototypeCreate(ctx context.Context, d *schema.ResourceData
 	version := d.Get("version").(string)
 	description := d.Get("description").(string)
 	active := d.Get("active").(bool)
-	organizationId := d.Get("organization_id").(string)
-	owningUserId := d.Get("owning_user_id").(string)
-	referencesStr := d.Get("references").(string)
-	var referencesData []xc.Reference
-	err := json.Unmarshal([]byte(referencesStr), &referencesData)
+	organizationId, err := getOrganizationId(d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	parameterDefinitionsStr := d.Get("parameter_definitions").(string)
-	var parameterDefinitionsData []xc.ParameterDefinition
-	err = json.Unmarshal([]byte(parameterDefinitionsStr), &parameterDefinitionsData)
+	owningUserId, err := getOwningUserId(d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	terraformStr := d.Get("terraform").(string)
-	var terraformData xc.Terraform
-	err = json.Unmarshal([]byte(terraformStr), &terraformData)
+	parameterDefinitions, err := expandPipelinePrototypeParameterDefinitions(d.Get("parameter_definition").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -101,12 +221,12 @@ func resourcePipelinePrototypeCreate(ctx context.Context, d *schema.ResourceData
 	location, err := c.CreatePipelinePrototype(&organizationId, &xc.PipelinePrototype{
 		Type:                 "pipeline-prototype",
 		Name:                 name,
-		References:           referencesData,
+		References:           expandPipelinePrototypeReferences(d.Get("reference").([]interface{})),
 		Version:              version,
 		Description:          description,
 		Active:               active,
-		ParameterDefinitions: parameterDefinitionsData,
-		Terraform:            terraformData,
+		ParameterDefinitions: parameterDefinitions,
+		Terraform:            expandPipelinePrototypeTerraform(d.Get("terraform").([]interface{})),
 		OrganizationId:       organizationId,
 		OwningUserId:         owningUserId,
 	})
@@ -118,6 +238,14 @@ func resourcePipelinePrototypeCreate(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(*id)
 
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
 	PipelinePrototype, err := c.GetPipelinePrototype(&organizationId, id)
 	if err != nil {
 		return diag.FromErr(err)
@@ -135,7 +263,7 @@ func resourcePipelinePrototypeCreate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourcePipelinePrototypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -144,6 +272,11 @@ func resourcePipelinePrototypeRead(ctx context.Context, d *schema.ResourceData,
 
 	pipelinePrototype, err := c.GetPipelinePrototype(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_pipeline_prototype (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -167,27 +300,19 @@ func resourcePipelinePrototypeRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	referencesData, err := json.Marshal(pipelinePrototype.References)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("references", string(referencesData)); err != nil {
+	if err := d.Set("reference", flattenPipelinePrototypeReferences(pipelinePrototype.References)); err != nil {
 		return diag.FromErr(err)
 	}
 
-	parameterDefinitionsData, err := json.Marshal(pipelinePrototype.ParameterDefinitions)
+	parameterDefinitions, err := flattenPipelinePrototypeParameterDefinitions(pipelinePrototype.ParameterDefinitions)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("parameter_definitions", string(parameterDefinitionsData)); err != nil {
+	if err := d.Set("parameter_definition", parameterDefinitions); err != nil {
 		return diag.FromErr(err)
 	}
 
-	terraformData, err := json.Marshal(pipelinePrototype.Terraform)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("terraform", string(terraformData)); err != nil {
+	if err := d.Set("terraform", flattenPipelinePrototypeTerraform(pipelinePrototype.Terraform)); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -211,7 +336,7 @@ func resourcePipelinePrototypeRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourcePipelinePrototypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	id := d.Id()
 	name := d.Get("name").(string)
@@ -220,36 +345,22 @@ func resourcePipelinePrototypeUpdate(ctx context.Context, d *schema.ResourceData
 	active := d.Get("active").(bool)
 	organizationId := d.Get("organization_id").(string)
 	owningUserId := d.Get("owning_user_id").(string)
-	referencesStr := d.Get("references").(string)
-	var referencesData []xc.Reference
-	err := json.Unmarshal([]byte(referencesStr), &referencesData)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	parameterDefinitionsStr := d.Get("parameter_definitions").(string)
-	var parameterDefinitionsData []xc.ParameterDefinition
-	err = json.Unmarshal([]byte(parameterDefinitionsStr), &parameterDefinitionsData)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	terraformStr := d.Get("terraform").(string)
-	var terraformData xc.Terraform
-	err = json.Unmarshal([]byte(terraformStr), &terraformData)
+	parameterDefinitions, err := expandPipelinePrototypeParameterDefinitions(d.Get("parameter_definition").([]interface{}))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	if d.HasChange("name") {
+	if d.HasChanges("name", "version", "description", "active", "reference", "parameter_definition", "terraform", "owning_user_id") {
 		err := c.UpdatePipelinePrototype(&organizationId, &xc.PipelinePrototype{
 			Type:                 "pipeline-prototype",
 			ID:                   id,
 			Name:                 name,
-			References:           referencesData,
+			References:           expandPipelinePrototypeReferences(d.Get("reference").([]interface{})),
 			Version:              version,
 			Description:          description,
 			Active:               active,
-			ParameterDefinitions: parameterDefinitionsData,
-			Terraform:            terraformData,
+			ParameterDefinitions: parameterDefinitions,
+			Terraform:            expandPipelinePrototypeTerraform(d.Get("terraform").([]interface{})),
 			OrganizationId:       organizationId,
 			OwningUserId:         owningUserId,
 		})
@@ -262,7 +373,7 @@ func resourcePipelinePrototypeUpdate(ctx context.Context, d *schema.ResourceData
 }
 
 func resourcePipelinePrototypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -278,3 +389,462 @@ func resourcePipelinePrototypeDelete(ctx context.Context, d *schema.ResourceData
 
 	return diags
 }
+
+func pipelinePrototypeReferenceResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"uri": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"filter_expression": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"required": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func pipelinePrototypeParameterDefinitionResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"label": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem:     translationResource(),
+			},
+			"help_text": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     translationResource(),
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"validation": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"function_name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+						},
+						"parameters": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+							StateFunc:        normalizeJson,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+						},
+					},
+				},
+			},
+			"auto_complete": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"read_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"placeholder": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     translationResource(),
+			},
+			"initial_value": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"option": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem:     translationResource(),
+						},
+						"value": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsJSON),
+							StateFunc:        normalizeJson,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+						},
+						"disabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"options_ref": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+						},
+						"name_expression": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+						},
+						"value_expression": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+						},
+					},
+				},
+			},
+			"decomposition_definition": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uri": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+						},
+						"decomposed_parameter_definition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+									},
+									"value_expression": {
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func pipelinePrototypeTerraformResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"sub_path": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"swan_repo_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+		},
+	}
+}
+
+func translationResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"default": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"en": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func expandPipelinePrototypeReferences(l []interface{}) []xc.Reference {
+	references := []xc.Reference{}
+	for _, raw := range l {
+		reference := raw.(map[string]interface{})
+		references = append(references, xc.Reference{
+			Id:               reference["id"].(string),
+			Uri:              reference["uri"].(string),
+			FilterExpression: reference["filter_expression"].(string),
+			Required:         reference["required"].(bool),
+		})
+	}
+
+	return references
+}
+
+func flattenPipelinePrototypeReferences(references []xc.Reference) []interface{} {
+	l := []interface{}{}
+	for _, reference := range references {
+		l = append(l, map[string]interface{}{
+			"id":                reference.Id,
+			"uri":               reference.Uri,
+			"filter_expression": reference.FilterExpression,
+			"required":          reference.Required,
+		})
+	}
+
+	return l
+}
+
+func expandPipelinePrototypeParameterDefinitions(l []interface{}) ([]xc.ParameterDefinition, error) {
+	parameterDefinitions := []xc.ParameterDefinition{}
+	for _, raw := range l {
+		parameterDefinition := raw.(map[string]interface{})
+
+		expanded := xc.ParameterDefinition{
+			Name:         parameterDefinition["name"].(string),
+			HelpText:     expandTranslation(parameterDefinition["help_text"].([]interface{})),
+			Type:         parameterDefinition["type"].(string),
+			AutoComplete: parameterDefinition["auto_complete"].(string),
+			ReadOnly:     parameterDefinition["read_only"].(bool),
+			Placeholder:  expandTranslation(parameterDefinition["placeholder"].([]interface{})),
+			InitialValue: parameterDefinition["initial_value"].(string),
+		}
+
+		if label := expandTranslation(parameterDefinition["label"].([]interface{})); label != nil {
+			expanded.Label = *label
+		}
+
+		if l := parameterDefinition["validation"].([]interface{}); len(l) > 0 && l[0] != nil {
+			v := l[0].(map[string]interface{})
+			parameters, err := expandJsonValue(v["parameters"].(string))
+			if err != nil {
+				return nil, err
+			}
+			expanded.Validation = &xc.Validation{
+				FunctionName: v["function_name"].(string),
+				Parameters:   parameters,
+			}
+		}
+
+		for _, raw := range parameterDefinition["option"].([]interface{}) {
+			option := raw.(map[string]interface{})
+			value, err := expandJsonValue(option["value"].(string))
+			if err != nil {
+				return nil, err
+			}
+			expandedOption := xc.Option{
+				Value:    value,
+				Disabled: option["disabled"].(bool),
+			}
+			if name := expandTranslation(option["name"].([]interface{})); name != nil {
+				expandedOption.Name = *name
+			}
+			expanded.Options = append(expanded.Options, expandedOption)
+		}
+
+		if l := parameterDefinition["options_ref"].([]interface{}); len(l) > 0 && l[0] != nil {
+			optionsRef := l[0].(map[string]interface{})
+			expanded.OptionsRef = &xc.OptionsRef{
+				Id:              optionsRef["id"].(string),
+				NameExpression:  optionsRef["name_expression"].(string),
+				ValueExpression: optionsRef["value_expression"].(string),
+			}
+		}
+
+		if l := parameterDefinition["decomposition_definition"].([]interface{}); len(l) > 0 && l[0] != nil {
+			decompositionDefinition := l[0].(map[string]interface{})
+			decomposedParameterDefinitions := []xc.DecomposedParameterDefinition{}
+			for _, raw := range decompositionDefinition["decomposed_parameter_definition"].([]interface{}) {
+				decomposedParameterDefinition := raw.(map[string]interface{})
+				decomposedParameterDefinitions = append(decomposedParameterDefinitions, xc.DecomposedParameterDefinition{
+					Name:            decomposedParameterDefinition["name"].(string),
+					ValueExpression: decomposedParameterDefinition["value_expression"].(string),
+				})
+			}
+			expanded.DecompositionDefinition = &xc.DecompositionDefinition{
+				Uri:                            decompositionDefinition["uri"].(string),
+				DecomposedParameterDefinitions: decomposedParameterDefinitions,
+			}
+		}
+
+		parameterDefinitions = append(parameterDefinitions, expanded)
+	}
+
+	return parameterDefinitions, nil
+}
+
+func flattenPipelinePrototypeParameterDefinitions(parameterDefinitions []xc.ParameterDefinition) ([]interface{}, error) {
+	l := []interface{}{}
+	for _, parameterDefinition := range parameterDefinitions {
+		validations := []interface{}{}
+		if parameterDefinition.Validation != nil {
+			parameters, err := flattenJsonValue(parameterDefinition.Validation.Parameters)
+			if err != nil {
+				return nil, err
+			}
+			validations = append(validations, map[string]interface{}{
+				"function_name": parameterDefinition.Validation.FunctionName,
+				"parameters":    parameters,
+			})
+		}
+
+		options := []interface{}{}
+		for _, option := range parameterDefinition.Options {
+			value, err := flattenJsonValue(option.Value)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, map[string]interface{}{
+				"name":     flattenTranslation(&option.Name),
+				"value":    value,
+				"disabled": option.Disabled,
+			})
+		}
+
+		optionsRefs := []interface{}{}
+		if parameterDefinition.OptionsRef != nil {
+			optionsRefs = append(optionsRefs, map[string]interface{}{
+				"id":               parameterDefinition.OptionsRef.Id,
+				"name_expression":  parameterDefinition.OptionsRef.NameExpression,
+				"value_expression": parameterDefinition.OptionsRef.ValueExpression,
+			})
+		}
+
+		decompositionDefinitions := []interface{}{}
+		if parameterDefinition.DecompositionDefinition != nil {
+			decomposedParameterDefinitions := []interface{}{}
+			for _, decomposedParameterDefinition := range parameterDefinition.DecompositionDefinition.DecomposedParameterDefinitions {
+				decomposedParameterDefinitions = append(decomposedParameterDefinitions, map[string]interface{}{
+					"name":             decomposedParameterDefinition.Name,
+					"value_expression": decomposedParameterDefinition.ValueExpression,
+				})
+			}
+			decompositionDefinitions = append(decompositionDefinitions, map[string]interface{}{
+				"uri":                             parameterDefinition.DecompositionDefinition.Uri,
+				"decomposed_parameter_definition": decomposedParameterDefinitions,
+			})
+		}
+
+		l = append(l, map[string]interface{}{
+			"name":                     parameterDefinition.Name,
+			"label":                    flattenTranslation(&parameterDefinition.Label),
+			"help_text":                flattenTranslation(parameterDefinition.HelpText),
+			"type":                     parameterDefinition.Type,
+			"validation":               validations,
+			"auto_complete":            parameterDefinition.AutoComplete,
+			"read_only":                parameterDefinition.ReadOnly,
+			"placeholder":              flattenTranslation(parameterDefinition.Placeholder),
+			"initial_value":            parameterDefinition.InitialValue,
+			"option":                   options,
+			"options_ref":              optionsRefs,
+			"decomposition_definition": decompositionDefinitions,
+		})
+	}
+
+	return l, nil
+}
+
+func expandPipelinePrototypeTerraform(l []interface{}) xc.Terraform {
+	if len(l) == 0 || l[0] == nil {
+		return xc.Terraform{}
+	}
+
+	terraform := l[0].(map[string]interface{})
+
+	return xc.Terraform{
+		SubPath:    terraform["sub_path"].(string),
+		SwanRepoId: terraform["swan_repo_id"].(string),
+	}
+}
+
+func flattenPipelinePrototypeTerraform(terraform xc.Terraform) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"sub_path":     terraform.SubPath,
+			"swan_repo_id": terraform.SwanRepoId,
+		},
+	}
+}
+
+func expandTranslation(l []interface{}) *xc.Translation {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	translation := l[0].(map[string]interface{})
+
+	return &xc.Translation{
+		Default: translation["default"].(string),
+		En:      translation["en"].(string),
+	}
+}
+
+func flattenTranslation(translation *xc.Translation) []interface{} {
+	if translation == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"default": translation.Default,
+			"en":      translation.En,
+		},
+	}
+}
+
+// expandJsonValue decodes an optional JSON encoded attribute, such as an
+// option value, into the untyped value the API expects.
+func expandJsonValue(s string) (interface{}, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(s), &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func flattenJsonValue(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
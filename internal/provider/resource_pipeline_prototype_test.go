@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func testPipelinePrototypeConfig(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            "Website",
+		"version":         "1.0.0",
+		"description":     description,
+		"active":          true,
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"parameter_definition": []interface{}{
+			map[string]interface{}{
+				"name": "size",
+				"type": "select",
+				"label": []interface{}{
+					map[string]interface{}{"default": "Size", "en": "Size"},
+				},
+				"validation": []interface{}{
+					map[string]interface{}{"function_name": "required"},
+				},
+				"option": []interface{}{
+					map[string]interface{}{
+						"name":  []interface{}{map[string]interface{}{"default": "Small"}},
+						"value": `"small"`,
+					},
+					map[string]interface{}{
+						"name":     []interface{}{map[string]interface{}{"default": "Large"}},
+						"value":    `{ "cpu": 4 }`,
+						"disabled": true,
+					},
+				},
+				"decomposition_definition": []interface{}{
+					map[string]interface{}{
+						"uri": "/sizes",
+						"decomposed_parameter_definition": []interface{}{
+							map[string]interface{}{"name": "cpu", "value_expression": "$.cpu"},
+						},
+					},
+				},
+			},
+		},
+		"terraform": []interface{}{
+			map[string]interface{}{"sub_path": "terraform", "swan_repo_id": "swan-repo-1"},
+		},
+	}
+}
+
+func TestResourcePipelinePrototype_lifecycle(t *testing.T) {
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourcePipelinePrototype(), testProviderMeta(c))
+
+	state := r.apply(testPipelinePrototypeConfig("A static website"))
+
+	expected := xc.PipelinePrototype{
+		Type:        "pipeline-prototype",
+		ID:          state.ID,
+		Name:        "Website",
+		Version:     "1.0.0",
+		Description: "A static website",
+		Active:      true,
+		References:  []xc.Reference{},
+		ParameterDefinitions: []xc.ParameterDefinition{
+			{
+				Name:       "size",
+				Type:       "select",
+				Label:      xc.Translation{Default: "Size", En: "Size"},
+				Validation: &xc.Validation{FunctionName: "required"},
+				Options: []xc.Option{
+					{Name: xc.Translation{Default: "Small"}, Value: "small"},
+					{Name: xc.Translation{Default: "Large"}, Value: map[string]interface{}{"cpu": float64(4)}, Disabled: true},
+				},
+				DecompositionDefinition: &xc.DecompositionDefinition{
+					Uri: "/sizes",
+					DecomposedParameterDefinitions: []xc.DecomposedParameterDefinition{
+						{Name: "cpu", ValueExpression: "$.cpu"},
+					},
+				},
+			},
+		},
+		Terraform:      xc.Terraform{SubPath: "terraform", SwanRepoId: "swan-repo-1"},
+		OrganizationId: "org-1",
+		OwningUserId:   "user-1",
+		CreatedAt:      fakeTimestamp,
+		ModifiedAt:     fakeTimestamp,
+	}
+	if actual := *c.pipelinePrototypes[state.ID]; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("unexpected pipeline prototype:\n%#v\nexpected:\n%#v", actual, expected)
+	}
+
+	state = r.refresh()
+	if state.Attributes["parameter_definition.0.option.1.value"] != `{"cpu":4}` || state.Attributes["terraform.0.sub_path"] != "terraform" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+
+	if diff := r.plan(testPipelinePrototypeConfig("A static website")); diff != nil && !diff.Empty() {
+		t.Fatalf("expected no changes after refresh, got %v", diff)
+	}
+
+	r.apply(testPipelinePrototypeConfig("A static web site"))
+	if c.pipelinePrototypes[state.ID].Description != "A static web site" {
+		t.Fatal("expected the pipeline prototype to be updated")
+	}
+}
+
+func TestResourcePipelinePrototype_validation(t *testing.T) {
+	config := testPipelinePrototypeConfig("A static website")
+	config["reference"] = []interface{}{
+		map[string]interface{}{"id": "", "uri": "/git-repos"},
+	}
+	config["parameter_definition"].([]interface{})[0].(map[string]interface{})["option"].([]interface{})[0].(map[string]interface{})["value"] = "small"
+
+	diags := resourcePipelinePrototype().Validate(terraform.NewResourceConfigRaw(config))
+	if len(diags) != 2 {
+		t.Fatalf("expected the blank reference id and the invalid option value to be reported, got %v", diags)
+	}
+}
+
+func TestResourcePipelinePrototype_stateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":                    "pipeline-prototype-1",
+		"name":                  "Website",
+		"version":               "1.0.0",
+		"description":           "A static website",
+		"active":                true,
+		"references":            `[{"id":"gitRepo","uri":"/git-repos","required":true}]`,
+		"parameter_definitions": `[{"name":"size","label":{"default":"Size"},"options":[{"name":{"default":"Large"},"value":{"cpu":4}}]}]`,
+		"terraform":             `{"subPath":"terraform","swanRepoId":"swan-repo-1"}`,
+		"organization_id":       "org-1",
+		"owning_user_id":        "user-1",
+		"created_at":            fakeTimestamp,
+		"modified_at":           fakeTimestamp,
+	}
+
+	upgraded, err := resourcePipelinePrototypeStateUpgradeV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	data, err := json.Marshal(upgraded)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	value, err := ctyjson.Unmarshal(data, resourcePipelinePrototype().CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("expected the upgraded state to match the current schema, got %s", err)
+	}
+
+	attributes := terraform.NewInstanceStateShimmedFromValue(value, 1).Attributes
+	expected := map[string]string{
+		"reference.0.id":                                 "gitRepo",
+		"reference.0.required":                           "true",
+		"parameter_definition.0.label.0.default":         "Size",
+		"parameter_definition.0.option.0.name.0.default": "Large",
+		"parameter_definition.0.option.0.value":          `{"cpu":4}`,
+		"terraform.0.sub_path":                           "terraform",
+		"terraform.0.swan_repo_id":                       "swan-repo-1",
+	}
+	for k, v := range expected {
+		if attributes[k] != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, attributes[k])
+		}
+	}
+
+	delete(rawState, "references")
+	rawState["parameter_definitions"] = "size"
+	if _, err := resourcePipelinePrototypeStateUpgradeV0(context.Background(), rawState, nil); err == nil {
+		t.Fatal("expected parameter definitions that are not JSON to be an error")
+	}
+}
+
+func testAccPipelinePrototypeConfig(version string) string {
+	return fmt.Sprintf(`
+resource "xilution_pipeline_prototype" "test" {
+  name            = "Website"
+  version         = %q
+  description     = "A static website"
+  active          = true
+  organization_id = %q
+  owning_user_id  = %q
+
+  reference {
+    id       = "git-repo"
+    uri      = "/git-repos"
+    required = true
+  }
+
+  parameter_definition {
+    name = "branch"
+    type = "text"
+
+    label {
+      default = "Branch"
+    }
+  }
+
+  terraform {
+    sub_path     = "terraform"
+    swan_repo_id = "swan-repo-1"
+  }
+}
+`, version, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionPipelinePrototype_basic(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_pipeline_prototype.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_pipeline_prototype", "bison", "pipeline-prototypes"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccPipelinePrototypeConfig("1.0.0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version", "1.0.0"),
+					resource.TestCheckResourceAttr(resourceName, "active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "reference.0.id", "git-repo"),
+					resource.TestCheckResourceAttr(resourceName, "parameter_definition.0.label.0.default", "Branch"),
+					resource.TestCheckResourceAttr(resourceName, "terraform.0.swan_repo_id", "swan-repo-1"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccPipelinePrototypeConfig("1.1.0"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "version", "1.1.0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
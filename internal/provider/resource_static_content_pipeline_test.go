@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccStaticContentPipelineConfig(name string, branch string) string {
+	return testAccCloudProviderConfig("AWS") + testAccGitRepoConfig("website") + fmt.Sprintf(`
+resource "xilution_static_content_pipeline" "test" {
+  name              = %q
+  pipeline_type     = "AWS_SMALL"
+  cloud_provider_id = xilution_cloud_provider.test.id
+  git_repo_id       = xilution_git_repo.test.id
+  branch            = %q
+  organization_id   = %q
+  owning_user_id    = %q
+
+  stages {
+    name = "test"
+  }
+
+  stages {
+    name = "prod"
+  }
+}
+`, name, branch, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionStaticContentPipeline_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_static_content_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_static_content_pipeline", "coyote", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccStaticContentPipelineConfig("Static Content 1", "master"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Static Content 1"),
+					resource.TestCheckResourceAttr(resourceName, "branch", "master"),
+					resource.TestCheckResourceAttr(resourceName, "stages.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "stages.0.name", "test"),
+					resource.TestCheckResourceAttrPair(resourceName, "git_repo_id", "xilution_git_repo.test", "id"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccStaticContentPipelineConfig("Static Content 2", "main"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Static Content 2"),
+					resource.TestCheckResourceAttr(resourceName, "branch", "main"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceVpcPipelineEvent_provision(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	pipeline := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).apply(testVpcPipelineConfig("VPC 1"))
+
+	state := newTestResource(t, resourceVpcPipelineEvent(), testProviderMeta(c)).apply(map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"pipeline_id":     pipeline.ID,
+		"event_type":      "PROVISION",
+	})
+
+	if state.Attributes["event_type"] != "PROVISION" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+
+	status := c.pipelineStatus(pipeline.ID)
+	if status.InfrastructureStatus != CREATE_COMPLETE || status.ContinuousIntegrationStatus.LatestUpExecutionStatus != SUCCEEDED {
+		t.Fatalf("expected create to wait for the pipeline up to succeed, status is %+v", status)
+	}
+}
+
+func TestResourceVpcPipelineEvent_failedCreate(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	pipeline := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).apply(testVpcPipelineConfig("VPC 1"))
+	c.failingPipelines[pipeline.ID] = true
+
+	state, _ := newTestResource(t, resourceVpcPipelineEvent(), testProviderMeta(c)).applyWithError(map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"pipeline_id":     pipeline.ID,
+		"event_type":      "PROVISION",
+	})
+
+	if state == nil || state.ID == "" {
+		t.Fatal("expected the created event to be kept in state")
+	}
+	if state.Attributes["organization_id"] != "org-1" || state.Attributes["owning_user_id"] != "user-1" {
+		t.Fatalf("expected the organization and owner in state, got %v", state.Attributes)
+	}
+}
+
+func TestResourceVpcPipelineEvent_triggers(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	pipeline := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).apply(testVpcPipelineConfig("VPC 1"))
+
+	r := newTestResource(t, resourceVpcPipelineEvent(), testProviderMeta(c))
+	config := map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"pipeline_id":     pipeline.ID,
+		"event_type":      "PROVISION",
+		"triggers": map[string]interface{}{
+			"config_hash": "1",
+		},
+	}
+	first := r.apply(config).ID
+
+	if diff := r.plan(config); diff != nil && !diff.Empty() {
+		t.Fatalf("expected no changes, got %v", diff)
+	}
+
+	config["event_type"] = "REPROVISION"
+	second := r.apply(config).ID
+
+	config["triggers"] = map[string]interface{}{
+		"config_hash": "2",
+	}
+	state := r.apply(config)
+
+	if first == second || second == state.ID {
+		t.Fatalf("expected each change to create a new event, got %s, %s and %s", first, second, state.ID)
+	}
+	if state.Attributes["triggers.config_hash"] != "2" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+
+	expected := []string{"PROVISION", "REPROVISION", "REPROVISION"}
+	if actual := c.sentPipelineEvents(pipeline.ID); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected events %v, got %v", expected, actual)
+	}
+	if status := c.pipelineStatus(pipeline.ID); status.InfrastructureStatus != UPDATE_COMPLETE {
+		t.Fatalf("expected the last event to be waited for, status is %+v", status)
+	}
+
+	for key, value := range map[string]interface{}{"organization_id": "org-2", "owning_user_id": "user-2"} {
+		changed := map[string]interface{}{}
+		for k, v := range config {
+			changed[k] = v
+		}
+		changed[key] = value
+
+		if diff := r.plan(changed); diff == nil || !diff.RequiresNew() {
+			t.Errorf("%s: expected the change to replace the event, got %v", key, diff)
+		}
+	}
+}
+
+func TestResourceVpcPipelineEvent_onDestroy(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	pipeline := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).apply(testVpcPipelineConfig("VPC 1"))
+
+	config := map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"pipeline_id":     pipeline.ID,
+		"event_type":      "PROVISION",
+	}
+
+	r := newTestResource(t, resourceVpcPipelineEvent(), testProviderMeta(c))
+	if state := r.apply(config); state.Attributes["on_destroy"] != "NONE" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+	r.destroy()
+
+	if status := c.pipelineStatus(pipeline.ID); status.InfrastructureStatus != CREATE_COMPLETE {
+		t.Fatalf("expected the infrastructure to be left running, status is %+v", status)
+	}
+
+	config["on_destroy"] = "DEPROVISION"
+	r = newTestResource(t, resourceVpcPipelineEvent(), testProviderMeta(c))
+	r.apply(config)
+	r.destroy()
+
+	if status := c.pipelineStatus(pipeline.ID); status.InfrastructureStatus != NOT_FOUND {
+		t.Fatalf("expected destroy to wait for the infrastructure to be removed, status is %+v", status)
+	}
+
+	expected := []string{"PROVISION", "PROVISION", "DEPROVISION"}
+	if actual := c.sentPipelineEvents(pipeline.ID); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected events %v, got %v", expected, actual)
+	}
+}
+
+func TestResourceVpcPipelineEvent_onDestroyImport(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	pipeline := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).apply(testVpcPipelineConfig("VPC 1"))
+
+	config := map[string]interface{}{
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"pipeline_id":     pipeline.ID,
+		"event_type":      "PROVISION",
+	}
+	id := newTestResource(t, resourceVpcPipelineEvent(), testProviderMeta(c)).apply(config).ID
+
+	r := newTestResource(t, resourceVpcPipelineEvent(), testProviderMeta(c))
+	if state := r.importState("org-1/" + id); state.Attributes["on_destroy"] != "NONE" {
+		t.Fatalf("unexpected state: %v", state.Attributes)
+	}
+	if diff := r.plan(config); diff != nil && !diff.Empty() {
+		t.Fatalf("expected no changes, got %v", diff)
+	}
+}
+
+func testAccVpcPipelineEventConfig(eventType string) string {
+	return testAccVpcPipelineConfig("VPC") + fmt.Sprintf(`
+resource "xilution_vpc_pipeline_event" "test" {
+  pipeline_id     = xilution_vpc_pipeline.test.id
+  event_type      = %q
+  organization_id = %q
+  owning_user_id  = %q
+}
+`, eventType, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionVpcPipelineEvent_provision(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_vpc_pipeline_event.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_vpc_pipeline", "gazelle", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineEventConfig("PROVISION"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "pipeline_id", "xilution_vpc_pipeline.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "event_type", "PROVISION"),
+					testAccCheckPipelineInfrastructureStatus(api, "xilution_vpc_pipeline.test", CREATE_COMPLETE),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVpcPipelineEventTriggersConfig(configHash string) string {
+	return testAccVpcPipelineConfig("VPC") + fmt.Sprintf(`
+resource "xilution_vpc_pipeline_event" "test" {
+  pipeline_id     = xilution_vpc_pipeline.test.id
+  event_type      = "PROVISION"
+  organization_id = %q
+  owning_user_id  = %q
+
+  triggers = {
+    config_hash = %q
+  }
+}
+`, mockOrganizationId, mockUserId, configHash)
+}
+
+func TestAccXilutionVpcPipelineEvent_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_vpc_pipeline_event.test"
+	pipelineResourceName := "xilution_vpc_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_vpc_pipeline", "gazelle", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineEventTriggersConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "pipeline_id", pipelineResourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "event_type", "PROVISION"),
+					resource.TestCheckResourceAttr(resourceName, "on_destroy", "NONE"),
+					resource.TestCheckResourceAttr(resourceName, "triggers.config_hash", "1"),
+					testAccCheckPipelineInfrastructureStatus(api, pipelineResourceName, CREATE_COMPLETE),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineEventTriggersConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "triggers.config_hash", "2"),
+					testAccCheckPipelineInfrastructureStatus(api, pipelineResourceName, CREATE_COMPLETE),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportStateIdFunc(resourceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"on_destroy", "triggers"},
+			},
+			{
+				// Destroying the event with on_destroy = "NONE" leaves the
+				// pipeline's infrastructure running.
+				Config: testAccProviderConfig(api) + testAccVpcPipelineConfig("VPC"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPipelineInfrastructureStatus(api, pipelineResourceName, CREATE_COMPLETE),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,469 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+func testVpcPipelineConfig(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":              name,
+		"pipeline_type":     "AWS_SMALL",
+		"cloud_provider_id": "cloud-provider-1",
+		"organization_id":   "org-1",
+		"owning_user_id":    "user-1",
+	}
+}
+
+func TestResourceVpcPipeline_lifecycle(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	state := r.apply(testVpcPipelineConfig("VPC 1"))
+	id := state.ID
+	if c.vpcPipelines[id].Name != "VPC 1" {
+		t.Fatalf("expected the pipeline to be created, got %+v", c.vpcPipelines[id])
+	}
+
+	state = r.apply(testVpcPipelineConfig("VPC 2"))
+	if state.ID != id {
+		t.Fatalf("expected a rename to update in place, id changed from %s to %s", id, state.ID)
+	}
+	if c.vpcPipelines[id].Name != "VPC 2" {
+		t.Fatalf("expected the pipeline to be renamed, got %+v", c.vpcPipelines[id])
+	}
+
+	config := testVpcPipelineConfig("VPC 2")
+	config["cloud_provider_id"] = "cloud-provider-2"
+	if diff := r.plan(config); !diff.RequiresNew() {
+		t.Fatal("expected changing cloud_provider_id to require a new pipeline")
+	}
+
+	r.destroy()
+	if _, ok := c.vpcPipelines[id]; ok {
+		t.Fatal("expected the pipeline to be deleted")
+	}
+}
+
+func TestResourceVpcPipeline_deprovisionsOnDelete(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	state := r.apply(testVpcPipelineConfig("VPC 1"))
+	c.pipelineStatuses[state.ID] = []xc.PipelineStatus{fakeStatus(CREATE_COMPLETE, SUCCEEDED)}
+
+	r.destroy()
+
+	for _, event := range c.pipelineEvents {
+		if event.PipelineId == state.ID && event.EventType == "DEPROVISION" {
+			return
+		}
+	}
+	t.Fatal("expected a DEPROVISION event before the pipeline was deleted")
+}
+
+func TestResourceVpcPipeline_removedOutsideTerraform(t *testing.T) {
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	state := r.apply(testVpcPipelineConfig("VPC 1"))
+	delete(c.vpcPipelines, state.ID)
+
+	if state := r.refresh(); state != nil {
+		t.Fatalf("expected the pipeline to be removed from state, got %v", state)
+	}
+}
+
+func TestResourceVpcPipeline_destroyRemovedOutsideTerraform(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	config := testVpcPipelineConfig("VPC 1")
+	config["provisioned"] = true
+	state := r.apply(config)
+	delete(c.vpcPipelines, state.ID)
+
+	r.destroy()
+
+	if len(c.sentDeprovisionEvents()) != 0 {
+		t.Fatal("expected nothing to be deprovisioned")
+	}
+}
+
+func TestResourceVpcPipeline_import(t *testing.T) {
+	c := newFakeXilutionClient()
+	created := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).apply(testVpcPipelineConfig("VPC 1"))
+
+	state := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c)).importState("org-1/" + created.ID)
+	if state.Attributes["name"] != "VPC 1" || state.Attributes["organization_id"] != "org-1" {
+		t.Fatalf("unexpected imported state: %v", state.Attributes)
+	}
+}
+
+func testAccVpcPipelineConfig(name string) string {
+	return testAccCloudProviderConfig("AWS") + fmt.Sprintf(`
+resource "xilution_vpc_pipeline" "test" {
+  name              = %q
+  pipeline_type     = "AWS_SMALL"
+  cloud_provider_id = xilution_cloud_provider.test.id
+  organization_id   = %q
+  owning_user_id    = %q
+}
+`, name, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionVpcPipeline_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_vpc_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_vpc_pipeline", "gazelle", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineConfig("VPC 1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "VPC 1"),
+					resource.TestCheckResourceAttr(resourceName, "pipeline_type", "AWS_SMALL"),
+					resource.TestCheckResourceAttrPair(resourceName, "cloud_provider_id", "xilution_cloud_provider.test", "id"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccVpcPipelineConfig("VPC 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "VPC 2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceVpcPipeline_providerDefaults(t *testing.T) {
+	c := newFakeXilutionClient()
+	c.clients["client-1"] = &xc.Client{
+		ID:           "client-1",
+		ClientUserId: "user-9",
+	}
+	meta := &providerMeta{
+		xilutionClient: c,
+		organizationId: "org-1",
+		grantType:      "client_credentials",
+		clientId:       "client-1",
+	}
+
+	config := testVpcPipelineConfig("VPC 1")
+	delete(config, "organization_id")
+	delete(config, "owning_user_id")
+
+	state := newTestResource(t, resourceVpcPipeline(), meta).apply(config)
+
+	if state.Attributes["organization_id"] != "org-1" || state.Attributes["owning_user_id"] != "user-9" {
+		t.Fatalf("expected the provider defaults in state, got %v", state.Attributes)
+	}
+	if pipeline := c.vpcPipelines[state.ID]; pipeline.OrganizationId != "org-1" || pipeline.OwningUserId != "user-9" {
+		t.Fatalf("expected the pipeline to be created with the provider defaults, got %+v", pipeline)
+	}
+}
+
+func TestResourceVpcPipeline_status(t *testing.T) {
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	state := r.apply(testVpcPipelineConfig("VPC 1"))
+	if state.Attributes["infrastructure_status"] != NOT_FOUND {
+		t.Fatalf("expected a new pipeline to report %s, got %v", NOT_FOUND, state.Attributes)
+	}
+
+	c.pipelineStatuses[state.ID] = []xc.PipelineStatus{fakeStatus(CREATE_COMPLETE, SUCCEEDED)}
+
+	state = r.refresh()
+	if state.Attributes["infrastructure_status"] != CREATE_COMPLETE || state.Attributes["latest_up_execution_status"] != SUCCEEDED {
+		t.Fatalf("expected the pipeline status in state, got %v", state.Attributes)
+	}
+}
+
+func TestResourceVpcPipeline_provisioned(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	config := testVpcPipelineConfig("VPC 1")
+	config["provisioned"] = true
+	state := r.apply(config)
+	id := state.ID
+	if state.Attributes["infrastructure_status"] != CREATE_COMPLETE || state.Attributes["provisioned"] != "true" {
+		t.Fatalf("expected the pipeline to be provisioned, got %v", state.Attributes)
+	}
+
+	config["name"] = "VPC 2"
+	r.apply(config)
+
+	config["provisioned"] = false
+	state = r.apply(config)
+	if state.Attributes["infrastructure_status"] != NOT_FOUND {
+		t.Fatalf("expected the pipeline to be deprovisioned, got %v", state.Attributes)
+	}
+
+	config["provisioned"] = true
+	r.apply(config)
+
+	expected := []string{"PROVISION", "DEPROVISION", "PROVISION"}
+	if actual := c.sentPipelineEvents(id); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected events %v, got %v", expected, actual)
+	}
+}
+
+func TestResourceVpcPipeline_provisionedRemovedOutsideTerraform(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	config := testVpcPipelineConfig("VPC 1")
+	config["provisioned"] = true
+	id := r.apply(config).ID
+
+	c.pipelineStatuses[id] = []xc.PipelineStatus{fakeStatus(NOT_FOUND, "")}
+	if state := r.refresh(); state.Attributes["provisioned"] != "false" {
+		t.Fatalf("expected provisioned to reflect the missing infrastructure, got %v", state.Attributes)
+	}
+
+	r.apply(config)
+
+	expected := []string{"PROVISION", "PROVISION"}
+	if actual := c.sentPipelineEvents(id); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected events %v, got %v", expected, actual)
+	}
+}
+
+func TestResourceVpcPipeline_failedProvision(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	c.failingPipelines[fmt.Sprintf("%032x", 1)] = true
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	config := testVpcPipelineConfig("VPC 1")
+	config["provisioned"] = true
+	state, _ := r.applyWithError(config)
+
+	if state == nil || state.ID == "" {
+		t.Fatal("expected the created vpc pipeline to be kept in state")
+	}
+	if state.Attributes["organization_id"] != "org-1" || state.Attributes["owning_user_id"] != "user-1" {
+		t.Fatalf("expected the organization and owner in state, got %v", state.Attributes)
+	}
+
+	r.destroy()
+
+	if _, ok := c.vpcPipelines[state.ID]; ok {
+		t.Fatal("expected the vpc pipeline to be deleted")
+	}
+}
+
+func TestResourceVpcPipeline_destroyWithoutStatus(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	id := r.apply(testVpcPipelineConfig("VPC 1")).ID
+	c.pipelinesWithoutStatus[id] = true
+
+	r.destroy()
+
+	if len(c.sentDeprovisionEvents()) != 0 {
+		t.Fatal("expected a pipeline without a status not to be deprovisioned")
+	}
+	if _, ok := c.vpcPipelines[id]; ok {
+		t.Fatal("expected the vpc pipeline to be deleted")
+	}
+}
+
+func TestResourceVpcPipeline_retainInfrastructureOnDestroy(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	config := testVpcPipelineConfig("VPC 1")
+	config["provisioned"] = true
+	config["retain_infrastructure_on_destroy"] = true
+
+	if diags := resourceVpcPipeline().Validate(terraform.NewResourceConfigRaw(config)); len(diags) != 0 {
+		t.Fatalf("expected no warning until the pipeline is destroyed, got %v", diags)
+	}
+
+	id := r.apply(config).ID
+	_, diags := r.resource.Apply(context.Background(), r.state, &terraform.InstanceDiff{Destroy: true}, r.meta)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning about orphaned infrastructure, got %v", diags)
+	}
+
+	if _, ok := c.vpcPipelines[id]; ok {
+		t.Fatal("expected the pipeline to be deleted")
+	}
+
+	expected := []string{"PROVISION"}
+	if actual := c.sentPipelineEvents(id); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected events %v, got %v", expected, actual)
+	}
+}
+
+func TestResourceVpcPipeline_dependentPipelines(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+
+	config := testVpcPipelineConfig("VPC 1")
+	config["provisioned"] = true
+	vpcPipelineId := r.apply(config).ID
+
+	k8sPipelineId := newTestResource(t, resourceK8sPipeline(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "K8S 1",
+		"pipeline_type":   "AWS_SMALL",
+		"vpc_pipeline_id": vpcPipelineId,
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"provisioned":     true,
+	}).ID
+
+	wordPressPipelineConfig := testWordPressPipelineConfig("master")
+	wordPressPipelineConfig["k8s_pipeline_id"] = k8sPipelineId
+	wordPressPipelineConfig["provisioned"] = true
+	wordPressPipelineId := newTestResource(t, resourceWordPressPipeline(), testProviderMeta(c)).apply(wordPressPipelineConfig).ID
+
+	apiPipelineConfig := testApiPipelineConfig("master")
+	apiPipelineConfig["vpc_pipeline_id"] = vpcPipelineId
+	apiPipelineId := newTestResource(t, resourceApiPipeline(), testProviderMeta(c)).apply(apiPipelineConfig).ID
+
+	_, diags := r.resource.Apply(context.Background(), r.state, &terraform.InstanceDiff{Destroy: true}, r.meta)
+	if !diags.HasError() {
+		t.Fatal("expected destroy to be refused while pipelines depend on the vpc pipeline")
+	}
+	for _, id := range []string{k8sPipelineId, wordPressPipelineId, apiPipelineId} {
+		if !strings.Contains(diags[0].Detail, id) {
+			t.Fatalf("expected %s to be listed as a dependent, got %s", id, diags[0].Detail)
+		}
+	}
+	if len(c.sentDeprovisionEvents()) != 0 {
+		t.Fatal("expected nothing to be deprovisioned")
+	}
+
+	config["force_destroy"] = true
+	r.apply(config)
+	_, diags = r.resource.Apply(context.Background(), r.state, &terraform.InstanceDiff{Destroy: true}, r.meta)
+	if diags.HasError() {
+		t.Fatalf("destroy: %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, k8sPipelineId) {
+		t.Fatalf("expected a warning listing the deprovisioned pipelines, got %v", diags)
+	}
+
+	expected := []string{wordPressPipelineId, k8sPipelineId, vpcPipelineId}
+	if actual := c.sentDeprovisionEvents(); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected pipelines to be deprovisioned in the order %v, got %v", expected, actual)
+	}
+	if _, ok := c.vpcPipelines[vpcPipelineId]; ok {
+		t.Fatal("expected the vpc pipeline to be deleted")
+	}
+	if _, ok := c.k8sPipelines[k8sPipelineId]; !ok {
+		t.Fatal("expected the k8s pipeline record to be kept")
+	}
+}
+
+func TestResourceVpcPipeline_dependentPipelinesNotProvisioned(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	r := newTestResource(t, resourceVpcPipeline(), testProviderMeta(c))
+	vpcPipelineId := r.apply(testVpcPipelineConfig("VPC 1")).ID
+
+	k8sPipelineId := newTestResource(t, resourceK8sPipeline(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "K8S 1",
+		"pipeline_type":   "AWS_SMALL",
+		"vpc_pipeline_id": vpcPipelineId,
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	}).ID
+
+	r.destroy()
+
+	if _, ok := c.vpcPipelines[vpcPipelineId]; ok {
+		t.Fatal("expected the unprovisioned vpc pipeline to be deleted despite its dependents")
+	}
+	if _, ok := c.k8sPipelines[k8sPipelineId]; !ok {
+		t.Fatal("expected the k8s pipeline to be left alone")
+	}
+	if len(c.sentDeprovisionEvents()) != 0 {
+		t.Fatal("expected nothing to be deprovisioned")
+	}
+}
+
+func TestDeprovisionPipelines_deadline(t *testing.T) {
+	testShortenWaits(t)
+
+	c := newFakeXilutionClient()
+	k8sPipelineId := newTestResource(t, resourceK8sPipeline(), testProviderMeta(c)).apply(map[string]interface{}{
+		"name":            "K8S 1",
+		"pipeline_type":   "AWS_SMALL",
+		"vpc_pipeline_id": "vpc-pipeline-1",
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+		"provisioned":     true,
+	}).ID
+
+	organizationId := "org-1"
+	dependents := []dependentPipeline{
+		{
+			resourceType:            "xilution_k8s_pipeline",
+			id:                      k8sPipelineId,
+			owningUserId:            "user-1",
+			createPipelineEventFunc: c.CreateK8sPipelineEvent,
+			getPipelineStatusFunc: func() (*xc.PipelineStatus, error) {
+				pipeline, err := c.GetK8sPipeline(&organizationId, &k8sPipelineId)
+				if err != nil {
+					return nil, err
+				}
+				return pipeline.Status, nil
+			},
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	err := deprovisionPipelines(ctx, organizationId, dependents, time.Hour)
+	if err == nil || !strings.Contains(err.Error(), "delete timeout") {
+		t.Fatalf("expected the spent delete timeout to be reported, got %v", err)
+	}
+	if len(c.sentDeprovisionEvents()) != 0 {
+		t.Fatal("expected nothing to be deprovisioned")
+	}
+}
@@ -2,10 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	xc "github.com/xilution/xilution-client-go"
 )
 
@@ -15,6 +18,14 @@ func resourceWordPressPipeline() *schema.Resource {
 		ReadContext:   resourceWordPressPipelineRead,
 		UpdateContext: resourceWordPressPipelineUpdate,
 		DeleteContext: resourceWordPressPipelineDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateWithOrganizationId,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
@@ -25,12 +36,15 @@ func resourceWordPressPipeline() *schema.Resource {
 				Required: true,
 			},
 			"pipeline_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(pipelineTypes, false)),
 			},
 			"k8s_pipeline_id": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"git_repo_id": {
 				Type:     schema.TypeString,
@@ -54,11 +68,22 @@ func resourceWordPressPipeline() *schema.Resource {
 			},
 			"organization_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
 			},
 			"owning_user_id": {
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				Computed: true,
+			},
+			"provisioned": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"retain_infrastructure_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
 			},
 			"created_at": {
 				Type:     schema.TypeString,
@@ -68,12 +93,24 @@ func resourceWordPressPipeline() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"infrastructure_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_up_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"latest_down_execution_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
 func resourceWordPressPipelineCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -90,8 +127,14 @@ func resourceWordPressPipelineCreate(ctx context.Context, d *schema.ResourceData
 		}
 		mappedStages = append(mappedStages, newStage)
 	}
-	organizationId := d.Get("organization_id").(string)
-	owningUserId := d.Get("owning_user_id").(string)
+	organizationId, err := getOrganizationId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	owningUserId, err := getOwningUserId(d, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	location, err := c.CreateWordPressPipeline(&organizationId, &xc.WordPressPipeline{
 		Type:           "pipeline",
@@ -112,6 +155,29 @@ func resourceWordPressPipelineCreate(ctx context.Context, d *schema.ResourceData
 
 	d.SetId(*id)
 
+	if err := d.Set("organization_id", organizationId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("owning_user_id", owningUserId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("provisioned").(bool) {
+		getPipelineStatusFunc := func() (*xc.PipelineStatus, error) {
+			pipeline, err := c.GetWordPressPipeline(&organizationId, id)
+			if err != nil {
+				return nil, err
+			}
+			return pipeline.Status, nil
+		}
+
+		err = sendPipelineEvent(ctx, "PROVISION", organizationId, owningUserId, *id, d.Timeout(schema.TimeoutCreate), c.CreateWordPressPipelineEvent, getPipelineStatusFunc)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	wordPressPipeline, err := c.GetWordPressPipeline(&organizationId, id)
 	if err != nil {
 		return diag.FromErr(err)
@@ -125,11 +191,15 @@ func resourceWordPressPipelineCreate(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	if err := setPipelineStatus(d, wordPressPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
 func resourceWordPressPipelineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -138,6 +208,11 @@ func resourceWordPressPipelineRead(ctx context.Context, d *schema.ResourceData,
 
 	wordPressPipeline, err := c.GetWordPressPipeline(&organizationId, &id)
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_word_press_pipeline (%s) not found, removing from state", id)
+			d.SetId("")
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
@@ -192,11 +267,19 @@ func resourceWordPressPipelineRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := setPipelineStatus(d, wordPressPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setPipelineProvisioned(d, wordPressPipeline.Status); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
 func resourceWordPressPipelineUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	id := d.Id()
 	name := d.Get("name").(string)
@@ -215,7 +298,7 @@ func resourceWordPressPipelineUpdate(ctx context.Context, d *schema.ResourceData
 	organizationId := d.Get("organization_id").(string)
 	owningUserId := d.Get("owning_user_id").(string)
 
-	if d.HasChange("name") {
+	if d.HasChanges("name", "git_repo_id", "branch", "stages", "owning_user_id") {
 		err := c.UpdateWordPressPipeline(&organizationId, &xc.WordPressPipeline{
 			Type:           "pipeline",
 			ID:             id,
@@ -233,11 +316,31 @@ func resourceWordPressPipelineUpdate(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	getPipelineStatusFunc := func() (*xc.PipelineStatus, error) {
+		pipeline, err := c.GetWordPressPipeline(&organizationId, &id)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline.Status, nil
+	}
+
+	status, err := getPipelineStatusFunc()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if eventType := pipelineProvisioningEventType(d, status, "git_repo_id", "branch", "stages"); eventType != "" {
+		err = sendPipelineEvent(ctx, eventType, organizationId, owningUserId, id, d.Timeout(schema.TimeoutUpdate), c.CreateWordPressPipelineEvent, getPipelineStatusFunc)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceWordPressPipelineRead(ctx, d, m)
 }
 
 func resourceWordPressPipelineDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*xc.XilutionClient)
+	c := m.(xilutionClient)
 
 	var diags diag.Diagnostics
 
@@ -255,10 +358,21 @@ func resourceWordPressPipelineDelete(ctx context.Context, d *schema.ResourceData
 
 	status, err := getPipelineStatusFunc()
 	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] xilution_word_press_pipeline (%s) not found, nothing to delete", id)
+			return diags
+		}
 		return diag.FromErr(err)
 	}
 
-	if status.InfrastructureStatus != NOT_FOUND {
+	if isPipelineProvisioned(status) && d.Get("retain_infrastructure_on_destroy").(bool) {
+		log.Printf("[WARN] Retaining the infrastructure of pipeline %s", id)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The cloud infrastructure of pipeline %s was left running", id),
+			Detail:   retainedInfrastructureDetail,
+		})
+	} else if isPipelineProvisioned(status) {
 		_, err = c.CreateWordPressPipelineEvent(&organizationId, &xc.PipelineEvent{
 			Type:           "pipeline-event",
 			PipelineId:     id,
@@ -269,9 +383,8 @@ func resourceWordPressPipelineDelete(ctx context.Context, d *schema.ResourceData
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		time.Sleep(5 * time.Second)
 
-		err = waitForPipelineInfrastructureNotFound(30*time.Minute, 5*time.Second, getPipelineStatusFunc)
+		err = waitForPipelineInfrastructureNotFound(ctx, d.Timeout(schema.TimeoutDelete), getPipelineStatusFunc)
 		if err != nil {
 			return diag.FromErr(err)
 		}
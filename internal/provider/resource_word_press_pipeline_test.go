@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testWordPressPipelineConfig(branch string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":            "WordPress 1",
+		"pipeline_type":   "AWS_SMALL",
+		"k8s_pipeline_id": "k8s-pipeline-1",
+		"git_repo_id":     "git-repo-1",
+		"branch":          branch,
+		"stages": []interface{}{
+			map[string]interface{}{"name": "test"},
+			map[string]interface{}{"name": "prod"},
+		},
+		"organization_id": "org-1",
+		"owning_user_id":  "user-1",
+	}
+}
+
+func testAccWordPressPipelineConfig(name string, branch string) string {
+	return testAccGitRepoConfig("website") + fmt.Sprintf(`
+resource "xilution_word_press_pipeline" "test" {
+  name            = %q
+  pipeline_type   = "AWS_SMALL"
+  git_repo_id     = xilution_git_repo.test.id
+  branch          = %q
+  organization_id = %q
+  owning_user_id  = %q
+
+  stages {
+    name = "test"
+  }
+
+  stages {
+    name = "prod"
+  }
+}
+`, name, branch, mockOrganizationId, mockUserId)
+}
+
+func TestAccXilutionWordPressPipeline_basic(t *testing.T) {
+	testShortenWaits(t)
+
+	api := newMockXilutionApi(t)
+
+	resourceName := "xilution_word_press_pipeline.test"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy(api, "xilution_word_press_pipeline", "penguin", "pipelines"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(api) + testAccWordPressPipelineConfig("Word Press 1", "master"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Word Press 1"),
+					resource.TestCheckResourceAttr(resourceName, "branch", "master"),
+					resource.TestCheckResourceAttr(resourceName, "stages.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "stages.0.name", "test"),
+					resource.TestCheckResourceAttrPair(resourceName, "git_repo_id", "xilution_git_repo.test", "id"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(api) + testAccWordPressPipelineConfig("Word Press 2", "main"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "Word Press 2"),
+					resource.TestCheckResourceAttr(resourceName, "branch", "main"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIdFunc(resourceName),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,337 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	xc "github.com/xilution/xilution-client-go"
+)
+
+// tokenExpiryMargin is how long before it expires an access token is
+// replaced, so a request is never sent with a token that lapses in flight.
+const tokenExpiryMargin = time.Minute
+
+// accessToken is a Xilution OAuth access token and when it expires. A zero
+// ExpiresAt means the expiry is unknown and the token is used until the API
+// rejects it.
+type accessToken struct {
+	Token     string    `json:"access_token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// newAccessToken returns an access token that expires expiresIn seconds from
+// now or, when the token endpoint did not say, at the token's exp claim.
+func newAccessToken(token string, expiresIn int64) *accessToken {
+	t := &accessToken{
+		Token: token,
+	}
+
+	if expiresIn > 0 {
+		t.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	} else {
+		t.ExpiresAt = jwtExpiry(token)
+	}
+
+	return t
+}
+
+// valid reports whether the token can still be used for a while.
+func (t *accessToken) valid() bool {
+	if t == nil || t.Token == "" {
+		return false
+	}
+
+	return t.ExpiresAt.IsZero() || time.Now().Add(tokenExpiryMargin).Before(t.ExpiresAt)
+}
+
+// jwtExpiry returns the exp claim of a JWT, or the zero time when the token
+// is not a JWT or has no exp claim.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
+
+const tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+const jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+const jwtTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+// credentialsGrant is the form xc.NewXilutionClient authenticates with, for
+// the client_credentials and password grants.
+func credentialsGrant(grantType, scope, clientId, clientSecret, username, password string) url.Values {
+	data := url.Values{}
+	data.Set("grant_type", grantType)
+	data.Set("client_id", clientId)
+	data.Set("client_secret", clientSecret)
+	data.Set("username", username)
+	data.Set("password", password)
+	data.Set("scope", scope)
+
+	return data
+}
+
+// oidcGrant is the form that exchanges an OIDC identity token, e.g. one a CI
+// runner is issued, for a Xilution access token. The JWT bearer grant takes
+// the token as its assertion, any other grant as an RFC 8693 subject token.
+func oidcGrant(grantType, scope, clientId, oidcToken string) url.Values {
+	data := url.Values{}
+	data.Set("grant_type", grantType)
+	data.Set("scope", scope)
+	if clientId != "" {
+		data.Set("client_id", clientId)
+	}
+
+	if grantType == jwtBearerGrantType {
+		data.Set("assertion", oidcToken)
+	} else {
+		data.Set("subject_token", oidcToken)
+		data.Set("subject_token_type", jwtTokenType)
+	}
+
+	return data
+}
+
+// readOidcToken returns the OIDC token, reading it from the file when it is
+// not given directly. The file is read every time, since CI runners rotate it.
+func readOidcToken(oidcToken, oidcTokenFile string) (string, error) {
+	if oidcToken != "" {
+		return oidcToken, nil
+	}
+
+	path, err := expandHomeDir(oidcTokenFile)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read oidc_token_file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("oidc_token_file %s is empty", oidcTokenFile)
+	}
+
+	return token, nil
+}
+
+// tokenSource hands out the provider's access token. It requests a new one
+// when the current one is about to expire or the API has rejected it, and
+// only one request for a new token is made at a time however many resources
+// are being applied in parallel.
+type tokenSource struct {
+	mu    sync.Mutex
+	token *accessToken
+
+	// fetch requests a new access token. It is nil when the provider was
+	// handed a token it has no way to renew.
+	fetch func() (*accessToken, error)
+
+	cache    *tokenCache
+	cacheKey string
+}
+
+// accessToken returns a token that is valid for at least tokenExpiryMargin,
+// requesting a new one if need be.
+func (s *tokenSource) accessToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token.valid() {
+		return s.token.Token, nil
+	}
+
+	if s.token == nil && s.cache != nil {
+		if cached := s.cache.get(s.cacheKey); cached.valid() {
+			log.Printf("[DEBUG] Using the cached Xilution access token")
+			s.token = cached
+			return s.token.Token, nil
+		}
+	}
+
+	return s.renew()
+}
+
+// rejected replaces a token the API answered 401 to. The token is only
+// renewed once when several requests were rejected with it.
+func (s *tokenSource) rejected(token string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.Token != token && s.token.valid() {
+		return s.token.Token, nil
+	}
+
+	return s.renew()
+}
+
+func (s *tokenSource) renew() (string, error) {
+	if s.fetch == nil {
+		if s.token != nil {
+			return s.token.Token, nil
+		}
+		return "", fmt.Errorf("no Xilution access token available")
+	}
+
+	log.Printf("[DEBUG] Requesting a new Xilution access token")
+
+	token, err := s.fetch()
+	if err != nil {
+		return "", err
+	}
+	s.token = token
+
+	if s.cache != nil && !token.ExpiresAt.IsZero() {
+		if err := s.cache.put(s.cacheKey, token); err != nil {
+			log.Printf("[WARN] Unable to cache the Xilution access token: %s", err)
+		}
+	}
+
+	return s.token.Token, nil
+}
+
+// authHttpClient authorizes every request with the token source's current
+// access token. When the API answers 401 it gets a new token and sends the
+// request once more. A 404 answer is returned as a notFoundError.
+type authHttpClient struct {
+	httpClient xc.IHttpClient
+	tokens     *tokenSource
+}
+
+func (c *authHttpClient) Do(req *retryablehttp.Request) (*http.Response, error) {
+	res, err := c.send(req)
+
+	return checkNotFound(req, res, err)
+}
+
+func (c *authHttpClient) send(req *retryablehttp.Request) (*http.Response, error) {
+	token, err := c.tokens.accessToken()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	res, err := c.httpClient.Do(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	io.Copy(ioutil.Discard, res.Body)
+	res.Body.Close()
+
+	log.Printf("[DEBUG] Xilution rejected the access token for %s %s, renewing it", req.Method, req.URL)
+
+	token, err = c.tokens.rejected(token)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	return c.httpClient.Do(req)
+}
+
+// tokenCache persists access tokens between runs in a JSON file, keyed by a
+// hash of what they were issued for. Only the tokens are written, never the
+// credentials used to get them.
+type tokenCache struct {
+	path string
+}
+
+// tokenCacheKey identifies the credentials and endpoint a token was issued
+// for.
+func tokenCacheKey(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\n")))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *tokenCache) read() map[string]*accessToken {
+	tokens := map[string]*accessToken{}
+
+	data, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] Unable to read the Xilution token cache %s: %s", c.path, err)
+		}
+		return tokens
+	}
+
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		log.Printf("[WARN] Ignoring the unreadable Xilution token cache %s: %s", c.path, err)
+		return map[string]*accessToken{}
+	}
+
+	return tokens
+}
+
+func (c *tokenCache) get(key string) *accessToken {
+	return c.read()[key]
+}
+
+// put stores a token, dropping expired ones. The file is replaced with a
+// rename so a concurrent run never reads a partly written cache.
+func (c *tokenCache) put(key string, token *accessToken) error {
+	tokens := c.read()
+	for k, t := range tokens {
+		if !t.valid() {
+			delete(tokens, k)
+		}
+	}
+	tokens[key] = token
+
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile(dir, ".xilution-token-cache-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), c.path)
+}
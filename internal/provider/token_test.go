@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testConfigureProvider(t *testing.T, api *mockXilutionApi, raw map[string]interface{}) *providerMeta {
+	t.Helper()
+
+	config := map[string]interface{}{
+		"base_url":        api.URL(),
+		"organization_id": mockOrganizationId,
+		"client_id":       mockClientId,
+		"client_secret":   mockClientSecret,
+	}
+	for k, v := range raw {
+		config[k] = v
+	}
+
+	p := Provider()
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("configure: %v", diags)
+	}
+
+	return p.Meta().(*providerMeta)
+}
+
+func TestProvider_renewsRejectedToken(t *testing.T) {
+	api := newMockXilutionApi(t)
+	c := testConfigureProvider(t, api, nil)
+	organizationId := mockOrganizationId
+
+	api.revokeAccessToken()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetOrganization(&organizationId); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("expected requests with a rejected token to be sent again, got %s", err)
+	}
+	if tokens := api.tokenCount(); tokens != 2 {
+		t.Fatalf("expected a single new token for all the rejected requests, %d tokens were issued", tokens)
+	}
+}
+
+func TestProvider_renewsExpiringToken(t *testing.T) {
+	api := newMockXilutionApi(t)
+	api.expiresIn = 30
+
+	c := testConfigureProvider(t, api, nil)
+	organizationId := mockOrganizationId
+
+	if _, err := c.GetOrganization(&organizationId); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tokens := api.tokenCount(); tokens != 2 {
+		t.Fatalf("expected a token about to expire to be renewed, %d tokens were issued", tokens)
+	}
+}
+
+func TestProvider_tokenCacheFile(t *testing.T) {
+	api := newMockXilutionApi(t)
+	api.expiresIn = 3600
+
+	tokenCacheFile := filepath.Join(t.TempDir(), "xilution", "token-cache.json")
+	config := map[string]interface{}{
+		"token_cache_file": tokenCacheFile,
+	}
+
+	testConfigureProvider(t, api, config)
+	c := testConfigureProvider(t, api, config)
+
+	organizationId := mockOrganizationId
+	if _, err := c.GetOrganization(&organizationId); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tokens := api.tokenCount(); tokens != 1 {
+		t.Fatalf("expected the cached token to be reused, %d tokens were issued", tokens)
+	}
+
+	info, err := os.Stat(tokenCacheFile)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected the token cache to be readable by its owner only, mode is %s", info.Mode())
+	}
+
+	api.revokeAccessToken()
+	if _, err := c.GetOrganization(&organizationId); err != nil {
+		t.Fatalf("expected a rejected cached token to be renewed, got %s", err)
+	}
+
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":         api.URL(),
+		"organization_id":  mockOrganizationId,
+		"client_id":        "client-2",
+		"client_secret":    "wrong",
+		"token_cache_file": tokenCacheFile,
+	}))
+	if !diags.HasError() {
+		t.Fatal("expected a token cached for other credentials not to be used")
+	}
+}
+
+func TestProvider_accessToken(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":        api.URL(),
+		"organization_id": mockOrganizationId,
+		"owning_user_id":  mockUserId,
+		"access_token":    mockAccessToken,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configure: %v", diags)
+	}
+
+	organizationId := mockOrganizationId
+	if _, err := p.Meta().(*providerMeta).GetOrganization(&organizationId); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tokens := api.tokenCount(); tokens != 0 {
+		t.Fatalf("expected the access token to be used as is, %d tokens were issued", tokens)
+	}
+}
+
+func TestProvider_oidcToken(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	oidcTokenFile := filepath.Join(t.TempDir(), "oidc-token")
+	if err := ioutil.WriteFile(oidcTokenFile, []byte(mockOidcToken+"\n"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, config := range []map[string]interface{}{
+		{"oidc_token_file": oidcTokenFile},
+		{"oidc_token": mockOidcToken, "grant_type": jwtBearerGrantType},
+	} {
+		config["base_url"] = api.URL()
+		config["organization_id"] = mockOrganizationId
+		config["owning_user_id"] = mockUserId
+
+		p := Provider()
+		if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+			t.Fatalf("%v: configure: %v", config, diags)
+		}
+
+		organizationId := mockOrganizationId
+		if _, err := p.Meta().(*providerMeta).GetOrganization(&organizationId); err != nil {
+			t.Fatalf("%v: %s", config, err)
+		}
+	}
+
+	if err := ioutil.WriteFile(oidcTokenFile, []byte("expired-oidc-token"), 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"base_url":        api.URL(),
+		"organization_id": mockOrganizationId,
+		"owning_user_id":  mockUserId,
+		"oidc_token_file": oidcTokenFile,
+	}))
+	if !diags.HasError() {
+		t.Fatal("expected a rejected oidc token to fail")
+	}
+}
+
+func TestProvider_oidcTokenGrantType(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	config := map[string]interface{}{
+		"base_url":                api.URL(),
+		"organization_id":         mockOrganizationId,
+		"owning_user_id":          mockUserId,
+		"oidc_token":              mockOidcToken,
+		"shared_credentials_file": testSharedCredentialsFile(t, "[default]\ngrant_type = client_credentials\n"),
+	}
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); diags.HasError() {
+		t.Fatalf("expected the profile's grant_type to be ignored for an oidc token, got %v", diags)
+	}
+
+	config["grant_type"] = "password"
+	if diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Fatal("expected a grant_type that cannot exchange an oidc token to be rejected")
+	}
+}
+
+func TestProvider_tokenRequiresOwningUserId(t *testing.T) {
+	api := newMockXilutionApi(t)
+
+	for _, config := range []map[string]interface{}{
+		{"access_token": mockAccessToken},
+		{"oidc_token": mockOidcToken},
+	} {
+		config["base_url"] = api.URL()
+		config["organization_id"] = mockOrganizationId
+
+		diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+		if !diags.HasError() || !strings.Contains(diags[0].Detail, "owning_user_id") {
+			t.Fatalf("%v: expected owning_user_id to be required, got %v", config, diags)
+		}
+	}
+}
+
+func TestJwtExpiry(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user-1","exp":%d}`, exp.Unix())))
+
+	if actual := jwtExpiry("header." + payload + ".signature"); !actual.Equal(exp) {
+		t.Fatalf("expected %s, got %s", exp, actual)
+	}
+
+	for _, token := range []string{"mock-access-token", "header.e30.signature", "header.!.signature"} {
+		if actual := jwtExpiry(token); !actual.IsZero() {
+			t.Errorf("%s: expected no expiry, got %s", token, actual)
+		}
+	}
+}
+
+func TestTokenSource_staticToken(t *testing.T) {
+	tokens := &tokenSource{
+		token: &accessToken{Token: "token-1"},
+	}
+
+	token, err := tokens.accessToken()
+	if err != nil || token != "token-1" {
+		t.Fatalf("expected token-1, got %q (%v)", token, err)
+	}
+
+	if token, err := tokens.rejected(token); err != nil || token != "token-1" {
+		t.Fatalf("expected a token that cannot be renewed to be kept, got %q (%v)", token, err)
+	}
+}